@@ -0,0 +1,23 @@
+package fancylists
+
+import "bytes"
+
+// defaultAbbreviations is the stop-list used unless Abbreviations is set.
+var defaultAbbreviations = []string{"vs.", "etc.", "e.g.", "no.", "ca."}
+
+// isAbbreviation reports whether marker, a letter marker with its
+// delimiter such as "vs.", is on the stop-list: Abbreviations, or
+// defaultAbbreviations when it is nil. Case is ignored, so "No." matches
+// "no.".
+func (e *FancyListsOptions) isAbbreviation(marker []byte) bool {
+	abbreviations := e.Abbreviations
+	if abbreviations == nil {
+		abbreviations = defaultAbbreviations
+	}
+	for _, abbr := range abbreviations {
+		if bytes.EqualFold(marker, []byte(abbr)) {
+			return true
+		}
+	}
+	return false
+}
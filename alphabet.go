@@ -0,0 +1,76 @@
+package fancylists
+
+// alphabetValue converts an alphabetic marker to its start value using the
+// configured Alphabet, or the Latin alphabet when none is set, and the
+// configured AlphaNumbering. Like alphabeticToNumber it returns 0 for
+// letters outside the alphabet and for values above maxStartValue.
+func (e *FancyListsOptions) alphabetValue(marker []byte) int {
+	if e.Alphabet == "" && e.AlphaNumbering == AlphaBijective {
+		return alphabeticToNumber(marker)
+	}
+	if len(marker) == 0 {
+		return 0
+	}
+
+	alphabet := e.Alphabet
+	if alphabet == "" {
+		alphabet = latinAlphabet
+	}
+	result := 0
+	base := len(alphabet)
+
+	for _, c := range marker {
+		digit := alphabetIndex(alphabet, c) + 1
+		if digit == 0 {
+			return 0 // Not in the alphabet
+		}
+		if e.AlphaNumbering == AlphaPositional {
+			digit-- // Letters are digits from zero
+		}
+		if result > (maxStartValue-digit)/base {
+			return 0 // Out of range
+		}
+		result = result*base + digit
+	}
+
+	if e.AlphaNumbering == AlphaPositional {
+		if result >= maxStartValue {
+			return 0 // Out of range
+		}
+		result++
+	}
+	return result
+}
+
+// alphabetIndex returns the position of the letter c in alphabet, ignoring
+// case, or -1 if it is not present.
+func alphabetIndex(alphabet string, c byte) int {
+	for i := 0; i < len(alphabet); i++ {
+		if alphabet[i]|0x20 == c|0x20 {
+			return i
+		}
+	}
+	return -1
+}
+
+// inAlphabet reports whether every letter of marker belongs to the configured
+// Alphabet. Roman numerals are always recognized, so markers that parse as
+// one are accepted even when the alphabet omits their letters.
+func (e *FancyListsOptions) inAlphabet(marker []byte) bool {
+	if _, ok := hashHint(marker); ok {
+		// The hint of a '#c.' marker is read in the list's type
+		return true
+	}
+	if e.Alphabet == "" {
+		return true
+	}
+	if _, ok := romanToNumber(marker); ok {
+		return true
+	}
+	for _, c := range marker {
+		if alphabetIndex(e.Alphabet, c) < 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,160 @@
+package fancylists
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// User attributes reach the renderer as attributes of the list node, which
+// is where goldmark-attributes and WithBlockAttributes put them. An
+// AttributeProvider does the same for any other attribute syntax, so all of
+// them combine with the computed class and type in the same way.
+
+// AttributeProvider reads the user attributes another attribute syntax
+// gives a list (see WithAttributeProviders).
+type AttributeProvider interface {
+	// ListAttributes returns the attributes given to list, which is part of
+	// the parsed document. It may remove from the document the syntax it
+	// read, so it is not rendered.
+	ListAttributes(list *ast.List, reader text.Reader, pc parser.Context) []ast.Attribute
+}
+
+// attributeProviderTransformer applies the attributes of the configured
+// providers to every list. As with goldmark-attributes, attributes already
+// set on a list are kept, and the first provider to set a name wins.
+type attributeProviderTransformer struct {
+	providers []AttributeProvider
+}
+
+func (t *attributeProviderTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	var lists []*ast.List
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if list, ok := n.(*ast.List); ok && entering {
+			lists = append(lists, list)
+		}
+		return ast.WalkContinue, nil
+	})
+	for _, list := range lists {
+		for _, p := range t.providers {
+			for _, attr := range p.ListAttributes(list, reader, pc) {
+				if isInternalAttribute(attr.Name) {
+					continue
+				}
+				if _, exists := list.Attribute(attr.Name); !exists {
+					list.SetAttribute(attr.Name, attr.Value)
+				}
+			}
+		}
+	}
+}
+
+// AttributeBlocks returns a provider for extensions that parse an attribute
+// line into a block of their own of the given kind: the attributes of such
+// a block directly below a list, with no blank line between, are given to
+// the list, and the block is removed.
+func AttributeBlocks(kind ast.NodeKind) AttributeProvider {
+	return attributeBlocks{kind}
+}
+
+type attributeBlocks struct {
+	kind ast.NodeKind
+}
+
+func (p attributeBlocks) ListAttributes(list *ast.List, reader text.Reader, pc parser.Context) []ast.Attribute {
+	next := list.NextSibling()
+	if next == nil || next.Kind() != p.kind || next.HasBlankPreviousLines() {
+		return nil
+	}
+	attrs := next.Attributes()
+	next.Parent().RemoveChild(next.Parent(), next)
+	return attrs
+}
+
+// FirstLineAttributes returns a provider for goldmark's native attribute
+// syntax, as parser.WithAttribute reads it after headings, written at the
+// end of the first line of a list's first item:
+//
+//	a. First {.steps #setup}
+//	b. Second
+//
+// The attributes are removed from the item's text.
+func FirstLineAttributes() AttributeProvider {
+	return firstLineAttributes{}
+}
+
+type firstLineAttributes struct{}
+
+func (firstLineAttributes) ListAttributes(list *ast.List, reader text.Reader, pc parser.Context) []ast.Attribute {
+	item := list.FirstChild()
+	if item == nil {
+		return nil
+	}
+	block := item.FirstChild()
+	if block == nil || block.Kind() != ast.KindTextBlock && block.Kind() != ast.KindParagraph || block.Lines().Len() == 0 {
+		return nil
+	}
+	source := reader.Source()
+	line := block.Lines().At(0)
+	value := util.TrimRightSpace(line.Value(source))
+	if len(value) == 0 || value[len(value)-1] != '}' {
+		return nil
+	}
+	open := bytes.LastIndexByte(value, '{')
+	if open < 0 || open > 0 && util.IsEscapedPunctuation(value, open-1) {
+		return nil
+	}
+	attrReader := text.NewReader(value[open:])
+	attrs, ok := parser.ParseAttributes(attrReader)
+	if rest, _ := attrReader.PeekLine(); !ok || len(rest) > 0 {
+		return nil
+	}
+
+	// The attributes must be plain text written directly in the block: the
+	// text nodes covering them leave no gap for code spans or escapes
+	start, stop := line.Start+open, line.Start+len(value)
+	covered := -1
+	for c := block.FirstChild(); c != nil; c = c.NextSibling() {
+		t, ok := c.(*ast.Text)
+		if !ok || t.Segment.Stop <= start || t.Segment.Start >= stop {
+			continue
+		}
+		if covered < 0 && t.Segment.Start > start || covered >= 0 && t.Segment.Start != covered {
+			return nil
+		}
+		covered = t.Segment.Stop
+	}
+	if covered < stop {
+		return nil
+	}
+	var last *ast.Text
+	for c := block.FirstChild(); c != nil; {
+		next := c.NextSibling()
+		if t, ok := c.(*ast.Text); ok && t.Segment.Start < stop {
+			switch {
+			case t.Segment.Start >= start:
+				if last != nil && t.SoftLineBreak() {
+					last.SetSoftLineBreak(true)
+				}
+				block.RemoveChild(block, t)
+			case t.Segment.Stop > start:
+				t.Segment = t.Segment.WithStop(start)
+				last = t
+			default:
+				last = t
+			}
+		}
+		c = next
+	}
+	if last != nil {
+		last.Segment = last.Segment.TrimRightSpace(source)
+	}
+	result := make([]ast.Attribute, len(attrs))
+	for i, attr := range attrs {
+		result[i] = ast.Attribute{Name: attr.Name, Value: attr.Value}
+	}
+	return result
+}
@@ -0,0 +1,162 @@
+package fancylists
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// kindTestAttributes is the block of an attribute extension that, unlike
+// goldmark-attributes, leaves its "@{...}" lines in the document.
+var kindTestAttributes = ast.NewNodeKind("TestAttributes")
+
+type testAttributes struct {
+	ast.BaseBlock
+}
+
+func (n *testAttributes) Kind() ast.NodeKind { return kindTestAttributes }
+
+func (n *testAttributes) Dump(source []byte, level int) { ast.DumpHelper(n, source, level, nil, nil) }
+
+type testAttributesParser struct{}
+
+func (p *testAttributesParser) Trigger() []byte { return []byte{'@'} }
+
+func (p *testAttributesParser) Open(parent ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	reader.Advance(1)
+	attrs, ok := parser.ParseAttributes(reader)
+	if !ok {
+		return nil, parser.NoChildren
+	}
+	node := &testAttributes{}
+	for _, attr := range attrs {
+		node.SetAttribute(attr.Name, attr.Value)
+	}
+	reader.AdvanceToEOL()
+	return node, parser.NoChildren
+}
+
+func (p *testAttributesParser) Continue(node ast.Node, reader text.Reader, pc parser.Context) parser.State {
+	return parser.Close
+}
+
+func (p *testAttributesParser) Close(node ast.Node, reader text.Reader, pc parser.Context) {}
+
+func (p *testAttributesParser) CanInterruptParagraph() bool { return true }
+
+func (p *testAttributesParser) CanAcceptIndentedLine() bool { return false }
+
+// testAttributesRenderer shows attribute blocks left in the document.
+type testAttributesRenderer struct{}
+
+func (r *testAttributesRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(kindTestAttributes, func(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if entering {
+			_, _ = w.WriteString("<!-- attributes -->\n")
+		}
+		return ast.WalkSkipChildren, nil
+	})
+}
+
+func TestAttributeBlocks(t *testing.T) {
+	md := goldmark.New(
+		goldmark.WithExtensions(NewFancyLists(WithAttributeProviders(AttributeBlocks(kindTestAttributes)))),
+		goldmark.WithParserOptions(parser.WithBlockParsers(util.Prioritized(&testAttributesParser{}, 100))),
+		goldmark.WithRendererOptions(renderer.WithNodeRenderers(util.Prioritized(&testAttributesRenderer{}, 500))),
+	)
+	source := []byte("a. One\nb. Two\n@{.steps type=\"I\" id=\"setup\"}\n\n- Bullet\n\n@{.apart}\n")
+	want := `<ol class="fancy fl-ucroman steps" type="I" start="1" id="setup">
+<li>One</li>
+<li>Two</li>
+</ol>
+<ul>
+<li>Bullet</li>
+</ul>
+<!-- attributes -->
+`
+	var buf bytes.Buffer
+	if err := md.Convert(source, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestFirstLineAttributes(t *testing.T) {
+	md := goldmark.New(goldmark.WithExtensions(NewFancyLists(WithAttributeProviders(FirstLineAttributes()))))
+	for _, tc := range []struct {
+		desc, source, want string
+	}{
+		{
+			"attributes applied and removed",
+			"a. First {.steps #setup}\nb. Second\n",
+			`<ol class="fancy fl-lcalpha steps" type="a" start="1" id="setup">
+<li>First</li>
+<li>Second</li>
+</ol>
+`,
+		},
+		{
+			"soft line break kept",
+			"i. First *item* {data-x=\"1\"}\n   continued\nii. Second\n",
+			`<ol class="fancy fl-lcroman" type="i" start="1" data-x="1">
+<li>First <em>item</em>
+continued</li>
+<li>Second</li>
+</ol>
+`,
+		},
+		{
+			"loose items",
+			"1. First {.steps}\n\n2. Second\n",
+			`<ol class="fancy fl-num steps" type="1" start="1">
+<li>
+<p>First</p>
+</li>
+<li>
+<p>Second</p>
+</li>
+</ol>
+`,
+		},
+		{
+			"code span left alone",
+			"a. Write `{.x}`\n",
+			`<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>Write <code>{.x}</code></li>
+</ol>
+`,
+		},
+		{
+			"escaped brace left alone",
+			"a. Write \\{.x}\n",
+			`<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>Write {.x}</li>
+</ol>
+`,
+		},
+		{
+			"internal attributes ignored",
+			"a. First {fl-type=I}\n",
+			`<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>First</li>
+</ol>
+`,
+		},
+	} {
+		var buf bytes.Buffer
+		if err := md.Convert([]byte(tc.source), &buf); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tc.want {
+			t.Errorf("%s: got:\n%s\nwant:\n%s", tc.desc, buf.String(), tc.want)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package fancylists
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/text"
+)
+
+func BenchmarkListParserTrigger(b *testing.B) {
+	p := &fancyListParser{}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = p.Trigger()
+	}
+}
+
+func BenchmarkListItemParserTrigger(b *testing.B) {
+	p := &fancyListItemParser{}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = p.Trigger()
+	}
+}
+
+func BenchmarkGetListTypeFromMarker(b *testing.B) {
+	markers := [][]byte{[]byte("iii"), []byte("B"), []byte("#"), []byte("iv"), []byte("c")}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, m := range markers {
+			_, _ = getListTypeFromMarker(m, orderedListFancy)
+		}
+	}
+}
+
+func BenchmarkRomanToNumber(b *testing.B) {
+	markers := [][]byte{[]byte("iii"), []byte("IV"), []byte("ix"), []byte("Ivx")}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, m := range markers {
+			_, _ = romanToNumber(m)
+		}
+	}
+}
+
+func BenchmarkAlphabeticToNumber(b *testing.B) {
+	markers := [][]byte{[]byte("a"), []byte("AB"), []byte("zz")}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, m := range markers {
+			_ = alphabeticToNumber(m)
+		}
+	}
+}
+
+// benchmarkSources holds the document shapes used by the conversion benchmarks.
+var benchmarkSources = []struct {
+	name   string
+	source []byte
+}{
+	{"LongList", repeatLines(2000, "#. list item with some text\n")},
+	{"DeeplyNested", nestedList(60)},
+	{"MixedTypes", repeatLines(300, "1. number\n2. number\n\na. letter\nb. letter\n\ni. roman\nii. roman\n\n")},
+	{"LetterProse", repeatLines(2000, "Letters start this ordinary line of prose that is not a list.\n")},
+	{"LetterParagraphs", repeatLines(2000, "Each paragraph starts with a letter and ends here.\n\n")},
+	{"Continue", repeatLines(500, "i. roman item\n#. continued item\n")},
+	{"NestedOnOneLine", repeatLines(5000, "a. ")},
+}
+
+func repeatLines(n int, s string) []byte {
+	var buf bytes.Buffer
+	for i := 0; i < n; i++ {
+		buf.WriteString(s)
+	}
+	return buf.Bytes()
+}
+
+func nestedList(depth int) []byte {
+	var buf bytes.Buffer
+	for d := 0; d < depth; d++ {
+		buf.WriteString(strings.Repeat("   ", d))
+		buf.WriteString("a. nested item\n")
+	}
+	return buf.Bytes()
+}
+
+// BenchmarkConvert measures parsing and rendering together.
+func BenchmarkConvert(b *testing.B) {
+	md := goldmark.New(goldmark.WithExtensions(FancyLists))
+	for _, src := range benchmarkSources {
+		b.Run(src.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(src.source)))
+			for i := 0; i < b.N; i++ {
+				var out bytes.Buffer
+				if err := md.Convert(src.source, &out); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkParse measures parsing alone.
+func BenchmarkParse(b *testing.B) {
+	md := goldmark.New(goldmark.WithExtensions(FancyLists))
+	for _, src := range benchmarkSources {
+		b.Run(src.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(src.source)))
+			for i := 0; i < b.N; i++ {
+				_ = md.Parser().Parse(text.NewReader(src.source))
+			}
+		})
+	}
+}
+
+// BenchmarkRender measures rendering of an already parsed document.
+func BenchmarkRender(b *testing.B) {
+	md := goldmark.New(goldmark.WithExtensions(FancyLists))
+	for _, src := range benchmarkSources {
+		doc := md.Parser().Parse(text.NewReader(src.source))
+		b.Run(src.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(src.source)))
+			for i := 0; i < b.N; i++ {
+				var out bytes.Buffer
+				if err := md.Renderer().Render(&out, src.source, doc); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// proseLine starts with a letter, so it reaches both parsers as a trigger.
+var proseLine = []byte("Letters start this ordinary line of prose that is not a list.\n")
+
+func BenchmarkProseRejection(b *testing.B) {
+	b.Run("PreCheck", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = mayBeListItem(proseLine)
+		}
+	})
+	b.Run("FullParse", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = matchesListItem(proseLine, true)
+		}
+	})
+}
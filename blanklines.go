@@ -0,0 +1,56 @@
+package fancylists
+
+import (
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// endsAtBlankLines reports whether a list ends at the reader's current
+// blank line under DoubleBlankLineEnd: the line before it is blank too, and
+// the blank lines are not inside a fenced code block of an item.
+func (e *FancyListsOptions) endsAtBlankLines(reader text.Reader, pc parser.Context) bool {
+	if !e.DoubleBlankLineEnd {
+		return false
+	}
+	if last := pc.LastOpenedBlock().Node; last != nil && last.Kind() == ast.KindFencedCodeBlock {
+		return false
+	}
+	return followsBlankLine(reader)
+}
+
+// followsBlankLine reports whether the line before the reader's current line
+// exists and is blank once the container prefixes the block reader has
+// consumed on the current line are stripped from it too, so that a bare ">"
+// inside a block quote counts as blank.
+func followsBlankLine(reader text.Reader) bool {
+	source := reader.Source()
+	_, segment := reader.Position()
+	start := segment.Start
+	for start > 0 && source[start-1] != '\n' {
+		start--
+	}
+	if start == 0 {
+		return false
+	}
+	quotes := 0
+	for _, c := range source[start:segment.Start] {
+		if c == '>' {
+			quotes++
+		}
+	}
+	i := start - 1
+	for i > 0 && source[i-1] != '\n' {
+		i--
+	}
+	for ; i < start-1; i++ {
+		switch c := source[i]; {
+		case c == '>' && quotes > 0:
+			quotes--
+		case !util.IsSpace(c):
+			return false
+		}
+	}
+	return true
+}
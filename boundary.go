@@ -0,0 +1,52 @@
+package fancylists
+
+import (
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/util"
+)
+
+// Boundary comments written around lists when WithBoundaryComments is
+// enabled, for example:
+//
+//	<!-- fl:start type=lcroman -->
+//	<ol class="fancy fl-lcroman" type="i" start="1">
+//	...
+//	</ol>
+//	<!-- fl:end -->
+const (
+	boundaryStart = "<!-- fl:start type="
+	boundaryEnd   = "<!-- fl:end -->"
+)
+
+// boundaryType returns the type named in the start comment of n: the class
+// of its type without the "fl-" prefix ("lcroman"), or "bullet".
+func (r *fancyListHTMLRenderer) boundaryType(n *ast.List) string {
+	if !n.IsOrdered() {
+		return "bullet"
+	}
+	typ := listType(n)
+	if !r.options.bareOutput() {
+		typ = r.renderedType(n)
+	}
+	// The names stay those of the default classes whatever ClassMap says,
+	// so post-processors can rely on them
+	return strings.TrimPrefix(defaultClassMap.Class(typ), "fl-")
+}
+
+// writeBoundary writes the start comment of n when entering and its end
+// comment when leaving, on a line of their own unless the list is compact.
+func (r *fancyListHTMLRenderer) writeBoundary(w util.BufWriter, n *ast.List, entering bool) {
+	r.options.writeIndent(w, n)
+	if entering {
+		_, _ = w.WriteString(boundaryStart)
+		_, _ = w.WriteString(r.boundaryType(n))
+		_, _ = w.WriteString(" -->")
+	} else {
+		_, _ = w.WriteString(boundaryEnd)
+	}
+	if !r.options.Compact {
+		_ = w.WriteByte('\n')
+	}
+}
@@ -0,0 +1,79 @@
+package fancylists
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+// attrNameBullet holds the bullet character of lists whose markers are one
+// of the configured Bullets, such as '•'.
+var attrNameBullet = []byte("fl-bullet")
+
+// bulletMarker is the ast.List marker of lists using a configured bullet.
+// The bullet itself is kept in attrNameBullet.
+const bulletMarker = '-'
+
+// dashBullets are the bullets added by DashBullets: the en and em dashes.
+const dashBullets = "–—"
+
+// bullets returns the configured bullet characters, including the dashes
+// when DashBullets is enabled.
+func (e *FancyListsOptions) bullets() string {
+	if e.DashBullets {
+		return e.Bullets + dashBullets
+	}
+	return e.Bullets
+}
+
+// parseBulletListItem is the counterpart of parseListItem for the bullet
+// characters in bullets, such as "•" and "◦". ASCII characters in bullets
+// are ignored.
+func parseBulletListItem(line []byte, bullets string) ([6]int, listItemType) {
+	ret := [6]int{}
+	i := 0
+	l := len(line)
+	for ; i < l && i <= 3 && line[i] == ' '; i++ {
+	}
+	if i > 3 || i >= l {
+		return ret, notList
+	}
+	ret[1] = i
+	ret[2] = i
+
+	r, size := utf8.DecodeRune(line[i:])
+	if r < utf8.RuneSelf || r == utf8.RuneError || !strings.ContainsRune(bullets, r) {
+		return ret, notList
+	}
+	i += size
+	ret[3] = i
+	return finishListItem(line, i, ret, bulletList)
+}
+
+// markerBullet returns the bullet character of a matched marker written
+// with one of the configured Bullets, or nil for all other markers.
+func markerBullet(line []byte, match [6]int) []byte {
+	r, size := utf8.DecodeRune(line[match[2]:])
+	if r < utf8.RuneSelf || match[2]+size != match[3] || isParenthesized(r) {
+		return nil
+	}
+	return line[match[2]:match[3]]
+}
+
+// listBullet returns the bullet character recorded on a list, or nil.
+func listBullet(n ast.Node) []byte {
+	if v, ok := n.Attribute(attrNameBullet); ok {
+		if bullet, ok := v.([]byte); ok {
+			return bullet
+		}
+	}
+	return nil
+}
+
+// sameBullet reports whether a matched marker may continue list: both use
+// the same configured bullet, or neither uses one.
+func sameBullet(list ast.Node, line []byte, match [6]int) bool {
+	return bytes.Equal(markerBullet(line, match), listBullet(list))
+}
@@ -0,0 +1,104 @@
+package fancylists
+
+// Class names written by the renderer on ordered list elements.
+// Downstream CSS generators should reference these constants rather than
+// hard-coding the strings so they always match the rendered output.
+const (
+	// ClassFancy is added to every ordered list rendered by this extension.
+	ClassFancy = "fancy"
+	// ClassNumeric marks decimal lists (1., 2., 3.).
+	ClassNumeric = "fl-num"
+	// ClassLowerAlpha marks lowercase alphabetic lists (a., b., c.).
+	ClassLowerAlpha = "fl-lcalpha"
+	// ClassUpperAlpha marks uppercase alphabetic lists (A., B., C.).
+	ClassUpperAlpha = "fl-ucalpha"
+	// ClassLowerRoman marks lowercase roman numeral lists (i., ii., iii.).
+	ClassLowerRoman = "fl-lcroman"
+	// ClassUpperRoman marks uppercase roman numeral lists (I., II., III.).
+	ClassUpperRoman = "fl-ucroman"
+	// ClassPaddingPrefix is followed by the marker width on zero-padded
+	// numeric lists when PaddingClass is enabled ("fl-pad-3" for "003.").
+	ClassPaddingPrefix = "fl-pad-"
+	// ClassChecklist marks ordered lists of task items whose checkboxes
+	// replace the numbers when ChecklistReplace is enabled.
+	ClassChecklist = "fl-checklist"
+	// ClassMarked marks ordered lists whose items carry their marker in a
+	// ClassMarker span when WithMarkerSpans is enabled.
+	ClassMarked = "fl-marked"
+	// ClassMarker is the class of the span holding an item's marker.
+	ClassMarker = "fl-marker"
+	// ClassPeriod marks lists written with '.' delimiters ("a.") when
+	// WithDelimiterClasses is enabled.
+	ClassPeriod = "fl-period"
+	// ClassParen marks lists written with ')' delimiters ("a)") when
+	// WithDelimiterClasses is enabled.
+	ClassParen = "fl-paren"
+	// ClassQuiz marks uppercase alphabetic lists rendered as radio button
+	// quizzes when WithQuizzes is enabled.
+	ClassQuiz = "fl-quiz"
+)
+
+// Class names written on bullet list elements by nesting depth when
+// WithBulletClasses is enabled.
+const (
+	// ClassDisc marks bullet lists that are not nested in another list.
+	ClassDisc = "fl-disc"
+	// ClassCircle marks bullet lists nested in one other list.
+	ClassCircle = "fl-circle"
+	// ClassSquare marks bullet lists nested in two or more other lists.
+	ClassSquare = "fl-square"
+)
+
+// ClassMap maps an HTML ordered list type attribute value ("1", "a", "A",
+// "i", "I") to the class name the renderer writes for that type.
+type ClassMap map[string]string
+
+// defaultClassMap is the mapping used by the renderer and by Stylesheet
+// unless another is configured. It is never modified.
+var defaultClassMap = ClassMap{
+	"1": ClassNumeric,
+	"a": ClassLowerAlpha,
+	"A": ClassUpperAlpha,
+	"i": ClassLowerRoman,
+	"I": ClassUpperRoman,
+}
+
+// DefaultClassMap returns a copy of the mapping used by the renderer and by
+// Stylesheet unless WithClassMap configures another. Changing the copy has
+// no effect on them.
+func DefaultClassMap() ClassMap {
+	return defaultClassMap.with(nil)
+}
+
+// with returns a copy of m with the entries of overrides replacing its own.
+func (m ClassMap) with(overrides ClassMap) ClassMap {
+	c := make(ClassMap, len(m)+len(overrides))
+	for typ, class := range m {
+		c[typ] = class
+	}
+	for typ, class := range overrides {
+		c[typ] = class
+	}
+	return c
+}
+
+// classMap returns the class map of the renderer: the configured one, or
+// defaultClassMap.
+func (e *FancyListsOptions) classMap() ClassMap {
+	if e.ClassMap != nil {
+		return e.ClassMap
+	}
+	return defaultClassMap
+}
+
+// Class returns the class name for the given type attribute value.
+// Unknown types fall back to the numeric class.
+func (m ClassMap) Class(typ string) string {
+	if c, ok := m[typ]; ok {
+		return c
+	}
+	if c, ok := m["1"]; ok {
+		return c
+	}
+	return ClassNumeric
+}
@@ -0,0 +1,94 @@
+package fancylists
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/yuin/goldmark"
+)
+
+func TestClassMapFallback(t *testing.T) {
+	if got := DefaultClassMap().Class("i"); got != ClassLowerRoman {
+		t.Errorf("Class(%q) = %q, want %q", "i", got, ClassLowerRoman)
+	}
+	if got := DefaultClassMap().Class("x"); got != ClassNumeric {
+		t.Errorf("Class(%q) = %q, want %q", "x", got, ClassNumeric)
+	}
+	if got := (ClassMap{}).Class("a"); got != ClassNumeric {
+		t.Errorf("empty ClassMap Class(%q) = %q, want %q", "a", got, ClassNumeric)
+	}
+}
+
+func TestStylesheet(t *testing.T) {
+	css := Stylesheet(nil)
+	for _, want := range []string{
+		"ol." + ClassNumeric + " { list-style-type: decimal; }",
+		"ol." + ClassUpperRoman + " { list-style-type: upper-roman; }",
+		"ol." + ClassChecklist + " { list-style-type: none; }",
+		"ul." + ClassCircle + " { list-style-type: circle; }",
+		"ul." + ClassMarked + " { list-style-type: none; }",
+	} {
+		if !strings.Contains(css, want) {
+			t.Errorf("Stylesheet(nil) missing %q:\n%s", want, css)
+		}
+	}
+	custom := Stylesheet(ClassMap{"a": "letters"})
+	if !strings.Contains(custom, "ol.letters { list-style-type: lower-alpha; }") {
+		t.Errorf("Stylesheet(custom) did not use custom class:\n%s", custom)
+	}
+	if want := "ol." + ClassLowerRoman + " { list-style-type: lower-roman; }"; !strings.Contains(custom, want) {
+		t.Errorf("Stylesheet(custom) missing default %q:\n%s", want, custom)
+	}
+}
+
+func TestWithClassMap(t *testing.T) {
+	m := ClassMap{"a": "letters"}
+	md := goldmark.New(goldmark.WithExtensions(NewFancyLists(WithClassMap(m))))
+	// Neither the map passed in nor the default map's copy reach the renderer
+	m["a"] = "changed"
+	DefaultClassMap()["I"] = "changed"
+	var buf bytes.Buffer
+	if err := md.Convert([]byte("a. One\n\nI. One\n"), &buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `<ol class="fancy letters" type="a" start="1">
+<li>One</li>
+</ol>
+<ol class="fancy fl-ucroman" type="I" start="1">
+<li>One</li>
+</ol>
+`
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	// A map set through the field is copied by Extend
+	options := &FancyListsOptions{ClassMap: ClassMap{"A": "upper"}}
+	md = goldmark.New(goldmark.WithExtensions(options))
+	options.ClassMap["A"] = "changed"
+	buf.Reset()
+	if err := md.Convert([]byte("A. One\n"), &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `class="fancy upper"`) {
+		t.Errorf("field ClassMap not applied:\n%s", buf.String())
+	}
+}
+
+func TestCounterStyles(t *testing.T) {
+	if css := CounterStyles("", nil); css != "" {
+		t.Errorf("CounterStyles(\"\", nil) = %q, want empty", css)
+	}
+	css := CounterStyles("abcDE", nil)
+	for _, want := range []string{
+		`@counter-style ` + CounterStyleLowerAlphabet + ` { system: alphabetic; symbols: "a" "b" "c" "d" "e"; }`,
+		`@counter-style ` + CounterStyleUpperAlphabet + ` { system: alphabetic; symbols: "A" "B" "C" "D" "E"; }`,
+		"ol." + ClassLowerAlpha + " { list-style-type: " + CounterStyleLowerAlphabet + "; }",
+		"ol." + ClassUpperAlpha + " { list-style-type: " + CounterStyleUpperAlphabet + "; }",
+	} {
+		if !strings.Contains(css, want) {
+			t.Errorf("CounterStyles missing %q:\n%s", want, css)
+		}
+	}
+}
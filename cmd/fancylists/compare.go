@@ -0,0 +1,350 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/text"
+
+	fancylists "github.com/zmtcreative/gm-fancy-lists"
+)
+
+// runCompare implements the compare command.
+func runCompare(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("compare", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	pandoc := flags.Bool("pandoc", false, "compare list structures with the output of a local pandoc")
+	pandocPath := flags.String("pandoc-path", "pandoc", "pandoc `binary` to run with -pandoc")
+	configPath := flags.String("config", "", "configuration `file` (default the nearest "+strings.Join(configNames, ", ")+")")
+	flags.String("preset", "", "extension `preset`: pandoc, commonmark or html5 (default pandoc with -pandoc)")
+	flags.Bool("gfm", false, "parse GitHub Flavored Markdown (tables, strikethrough, task lists, autolinks)")
+	flags.Usage = func() {
+		fmt.Fprint(stderr, "usage: fancylists compare [flags] [file ...]\n\n"+
+			"Reports where the lists the extension parses differ from core Goldmark or,\n"+
+			"with -pandoc, from Pandoc. Reads standard input when no file is given.\n\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitSame
+		}
+		return exitError
+	}
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "fancylists: %v\n", err)
+		return exitError
+	}
+	cfg.override(flags)
+	if cfg.Preset == "" && *pandoc {
+		cfg.Preset = "pandoc"
+	}
+	opts, err := cfg.options()
+	if err != nil {
+		fmt.Fprintf(stderr, "fancylists: %v\n", err)
+		return exitError
+	}
+	if *pandoc {
+		path, err := exec.LookPath(*pandocPath)
+		if err != nil {
+			fmt.Fprintf(stderr, "fancylists: pandoc not found: %v (install pandoc or set -pandoc-path)\n", err)
+			return exitError
+		}
+		*pandocPath = path
+	}
+
+	files := flags.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	status := exitSame
+	for _, file := range files {
+		source, err := readInput(file, stdin)
+		if err != nil {
+			fmt.Fprintf(stderr, "fancylists: %v\n", err)
+			return exitError
+		}
+		var report string
+		if *pandoc {
+			report, err = comparePandoc(source, *pandocPath, opts, cfg.GFM)
+		} else {
+			report = compareCore(source, opts, cfg.GFM)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "fancylists: %s: %v\n", file, err)
+			return exitError
+		}
+		if report != "" {
+			fmt.Fprintf(stdout, "%s:\n%s", file, report)
+			status = exitDiffer
+		}
+	}
+	return status
+}
+
+// readInput returns the contents of file, or of stdin when file is "-".
+func readInput(file string, stdin io.Reader) ([]byte, error) {
+	if file == "-" {
+		return io.ReadAll(stdin)
+	}
+	return os.ReadFile(file)
+}
+
+// compareCore reports the differences CompareWithCore finds in source, one
+// per line, or "" if there are none. With gfm both parses include GitHub
+// Flavored Markdown. Documents with fancy markers are expected to differ
+// from core Goldmark and are not reported.
+func compareCore(source []byte, opts []fancylists.Option, gfm bool) string {
+	var extensions []goldmark.Extender
+	if gfm {
+		extensions = append(extensions, extension.GFM)
+	}
+	core := goldmark.New(goldmark.WithExtensions(extensions...)).Parser().Parse(text.NewReader(source))
+	doc := newMarkdown(opts, gfm).Parser().Parse(text.NewReader(source))
+	if fancylists.HasFancyMarkers(doc, source) {
+		return ""
+	}
+	diffs := fancylists.CompareTrees(core, doc, source)
+	var b strings.Builder
+	for _, d := range diffs {
+		b.WriteString("  " + d.String() + "\n")
+	}
+	return b.String()
+}
+
+// comparePandoc converts source with the pandoc binary at path and with the
+// extension, with GitHub Flavored Markdown if gfm is set, and returns a diff
+// of their list outlines, or "" if they match.
+func comparePandoc(source []byte, path string, opts []fancylists.Option, gfm bool) (string, error) {
+	cmd := exec.Command(path, "-f", "markdown", "-t", "json")
+	cmd.Stdin = bytes.NewReader(source)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("pandoc: %v: %s", err, msg)
+		}
+		return "", fmt.Errorf("pandoc: %v", err)
+	}
+	want, err := pandocOutline(out)
+	if err != nil {
+		return "", err
+	}
+	got := fancyOutline(source, opts, gfm)
+	if want == got {
+		return "", nil
+	}
+	return "--- pandoc\n+++ fancylists\n" + diffLines(want, got), nil
+}
+
+// diffLines returns a unified-style diff of the lines of a and b without
+// context headers: every line prefixed with "-", "+" or " ".
+func diffLines(a, b string) string {
+	x := strings.SplitAfter(a, "\n")
+	y := strings.SplitAfter(b, "\n")
+	// lcs[i][j] is the length of the longest common subsequence of x[i:]
+	// and y[j:].
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	var d strings.Builder
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			if x[i] != "" {
+				d.WriteString(" " + x[i])
+			}
+			i++
+			j++
+		case j < len(y) && (i == len(x) || lcs[i][j+1] >= lcs[i+1][j]):
+			d.WriteString("+" + y[j])
+			j++
+		default:
+			d.WriteString("-" + x[i])
+			i++
+		}
+	}
+	return d.String()
+}
+
+// listShape is the structure of one list as compared with Pandoc: what both
+// implementations record, without the item contents.
+type listShape struct {
+	depth     int
+	ordered   bool
+	typ       string
+	start     int
+	delimiter string
+	items     int
+	tight     bool
+}
+
+// String formats the list as one outline line indented by its depth, such
+// as "ol type=a start=1 delimiter=) items=3 tight".
+func (l listShape) String() string {
+	s := strings.Repeat("  ", l.depth)
+	if l.ordered {
+		s += "ol type=" + l.typ + " start=" + strconv.Itoa(l.start) + " delimiter=" + l.delimiter
+	} else {
+		s += "ul"
+	}
+	s += " items=" + strconv.Itoa(l.items)
+	if l.tight {
+		return s + " tight"
+	}
+	return s + " loose"
+}
+
+// outline returns the lines of lists, one per list.
+func outline(lists []listShape) string {
+	var b strings.Builder
+	for _, l := range lists {
+		b.WriteString(l.String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// fancyOutline parses source with the extension, and with GitHub Flavored
+// Markdown if gfm is set, and returns the outline of its lists in document
+// order.
+func fancyOutline(source []byte, opts []fancylists.Option, gfm bool) string {
+	doc := newMarkdown(opts, gfm).Parser().Parse(text.NewReader(source))
+	var lists []listShape
+	depth := 0
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		list, ok := n.(*ast.List)
+		if !ok {
+			return ast.WalkContinue, nil
+		}
+		if !entering {
+			depth--
+			return ast.WalkContinue, nil
+		}
+		l := listShape{depth: depth, ordered: list.IsOrdered(), items: list.ChildCount(), tight: list.IsTight}
+		if l.ordered {
+			l.typ = "1"
+			if v, ok := list.Attribute([]byte("type")); ok {
+				l.typ = fmt.Sprintf("%s", v)
+			}
+			l.start = list.Start
+			l.delimiter = fancylists.Delimiter(list)
+		}
+		lists = append(lists, l)
+		depth++
+		return ast.WalkContinue, nil
+	})
+	return outline(lists)
+}
+
+// Pandoc list number styles and delimiters as written in the outline.
+var (
+	pandocStyles = map[string]string{
+		"DefaultStyle": "1",
+		"Example":      "1",
+		"Decimal":      "1",
+		"LowerAlpha":   "a",
+		"UpperAlpha":   "A",
+		"LowerRoman":   "i",
+		"UpperRoman":   "I",
+	}
+	pandocDelimiters = map[string]string{
+		"DefaultDelim": ".",
+		"Period":       ".",
+		"OneParen":     ")",
+		"TwoParens":    "()",
+	}
+)
+
+// pandocOutline returns the outline of the lists in a document written by
+// "pandoc -t json".
+func pandocOutline(data []byte) (string, error) {
+	var doc struct {
+		Blocks []interface{} `json:"blocks"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return "", fmt.Errorf("reading pandoc output: %v", err)
+	}
+	var lists []listShape
+	pandocLists(doc.Blocks, 0, &lists)
+	return outline(lists), nil
+}
+
+// pandocLists appends the lists found in v, a decoded element of Pandoc's
+// JSON AST, to lists in document order. Every element is searched, so lists
+// inside block quotes, divs and other containers are found.
+func pandocLists(v interface{}, depth int, lists *[]listShape) {
+	switch v := v.(type) {
+	case []interface{}:
+		for _, e := range v {
+			pandocLists(e, depth, lists)
+		}
+	case map[string]interface{}:
+		content, _ := v["c"].([]interface{})
+		var l listShape
+		var items []interface{}
+		switch v["t"] {
+		case "BulletList":
+			items = content
+		case "OrderedList":
+			if len(content) != 2 {
+				return
+			}
+			l.ordered = true
+			if attrs, ok := content[0].([]interface{}); ok && len(attrs) == 3 {
+				start, _ := attrs[0].(float64)
+				l.start = int(start)
+				l.typ = pandocStyles[pandocTag(attrs[1])]
+				l.delimiter = pandocDelimiters[pandocTag(attrs[2])]
+			}
+			items, _ = content[1].([]interface{})
+		default:
+			pandocLists(v["c"], depth, lists)
+			return
+		}
+		l.depth = depth
+		l.items = len(items)
+		l.tight = true
+		for _, item := range items {
+			blocks, _ := item.([]interface{})
+			for _, block := range blocks {
+				if pandocTag(block) == "Para" {
+					l.tight = false
+				}
+			}
+		}
+		*lists = append(*lists, l)
+		pandocLists(items, depth+1, lists)
+	}
+}
+
+// pandocTag returns the constructor name of a Pandoc JSON element.
+func pandocTag(v interface{}) string {
+	m, _ := v.(map[string]interface{})
+	t, _ := m["t"].(string)
+	return t
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	fancylists "github.com/zmtcreative/gm-fancy-lists"
+)
+
+// pandocJSON is what "pandoc -f markdown -t json" writes for compareSource.
+const pandocJSON = `{"pandoc-api-version":[1,23,1],"meta":{},"blocks":[
+{"t":"OrderedList","c":[[1,{"t":"LowerAlpha"},{"t":"Period"}],[
+ [{"t":"Plain","c":[{"t":"Str","c":"one"}]}],
+ [{"t":"Plain","c":[{"t":"Str","c":"two"}]},
+  {"t":"OrderedList","c":[[2,{"t":"LowerRoman"},{"t":"OneParen"}],[
+   [{"t":"Plain","c":[{"t":"Str","c":"x"}]}],
+   [{"t":"Plain","c":[{"t":"Str","c":"y"}]}]]]}]]]},
+{"t":"BlockQuote","c":[
+ {"t":"BulletList","c":[
+  [{"t":"Para","c":[{"t":"Str","c":"z"}]}],
+  [{"t":"Para","c":[{"t":"Str","c":"w"}]}]]}]}]}`
+
+const compareSource = `a. one
+b. two
+   ii) x
+   iii) y
+
+> - z
+>
+> - w
+`
+
+const compareOutline = `ol type=a start=1 delimiter=. items=2 tight
+  ol type=i start=2 delimiter=) items=2 tight
+ul items=2 loose
+`
+
+func TestPandocOutline(t *testing.T) {
+	got, err := pandocOutline([]byte(pandocJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != compareOutline {
+		t.Errorf("pandocOutline:\n%s\nwant:\n%s", got, compareOutline)
+	}
+	if _, err := pandocOutline([]byte("not json")); err == nil {
+		t.Error("pandocOutline accepted invalid JSON")
+	}
+}
+
+func TestFancyOutline(t *testing.T) {
+	got := fancyOutline([]byte(compareSource), []fancylists.Option{fancylists.PandocCompat()}, false)
+	if got != compareOutline {
+		t.Errorf("fancyOutline:\n%s\nwant:\n%s", got, compareOutline)
+	}
+}
+
+// fakePandoc writes a script standing in for pandoc that prints output.
+func fakePandoc(t *testing.T, output string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake pandoc is a shell script")
+	}
+	dir := t.TempDir()
+	data := filepath.Join(dir, "out.json")
+	if err := os.WriteFile(data, []byte(output), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "pandoc")
+	script := "#!/bin/sh\ncat >/dev/null\ncat '" + data + "'\n"
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestComparePandoc(t *testing.T) {
+	pandoc := fakePandoc(t, pandocJSON)
+	var stdout, stderr bytes.Buffer
+	status := run([]string{"compare", "-pandoc", "-pandoc-path", pandoc}, strings.NewReader(compareSource), &stdout, &stderr)
+	if status != exitSame || stdout.Len() != 0 {
+		t.Errorf("matching input: status %d, output %q, errors %q", status, stdout.String(), stderr.String())
+	}
+
+	stdout.Reset()
+	status = run([]string{"compare", "-pandoc", "-pandoc-path", pandoc}, strings.NewReader("1. one\n2. two\n"), &stdout, &stderr)
+	if status != exitDiffer {
+		t.Errorf("differing input: status %d, want %d", status, exitDiffer)
+	}
+	for _, want := range []string{"-:\n", "--- pandoc", "-ol type=a start=1", "+ol type=1 start=1 delimiter=. items=2 tight\n"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("differing input: output does not contain %q:\n%s", want, stdout.String())
+		}
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	got := diffLines("a\nb\nc\n", "a\nc\nd\n")
+	want := " a\n-b\n c\n+d\n"
+	if got != want {
+		t.Errorf("diffLines = %q, want %q", got, want)
+	}
+}
+
+func TestComparePandocMissing(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	missing := filepath.Join(t.TempDir(), "pandoc")
+	status := run([]string{"compare", "-pandoc", "-pandoc-path", missing}, strings.NewReader(""), &stdout, &stderr)
+	if status != exitError || !strings.Contains(stderr.String(), "pandoc not found") {
+		t.Errorf("status %d, errors %q", status, stderr.String())
+	}
+}
+
+func TestCompareCore(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	status := run([]string{"compare"}, strings.NewReader("1. one\n2. two\n\n- a\n"), &stdout, &stderr)
+	if status != exitSame || stdout.Len() != 0 {
+		t.Errorf("status %d, output %q, errors %q", status, stdout.String(), stderr.String())
+	}
+	status = run([]string{"compare", "-gfm"}, strings.NewReader("1. [x] done\n2. [ ] todo\n\n| a |\n|---|\n| b |\n"), &stdout, &stderr)
+	if status != exitSame || stdout.Len() != 0 {
+		t.Errorf("-gfm: status %d, output %q, errors %q", status, stdout.String(), stderr.String())
+	}
+	status = run([]string{"compare", "-preset", "nope"}, strings.NewReader(""), &stdout, &stderr)
+	if status != exitError {
+		t.Errorf("unknown preset: status %d, want %d", status, exitError)
+	}
+}
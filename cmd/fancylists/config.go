@@ -0,0 +1,252 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	fancylists "github.com/zmtcreative/gm-fancy-lists"
+)
+
+// configNames are the configuration files looked for in the working
+// directory and its parents, in order of preference.
+var configNames = []string{".fancylists.yaml", ".fancylists.yml", ".fancylists.toml"}
+
+// config holds the settings a configuration file declares. Flags given on
+// the command line override them.
+//
+//	preset: pandoc
+//	gfm: true
+//	strict: true
+//	types: [1, a, i]
+//	mixed-case: diagnose
+//	classes:
+//	  delimiters: true
+//	  bullets: true
+//	  types: {a: letters, I: chapters}
+type config struct {
+	// Preset names the extension preset applied before the other options.
+	Preset string `yaml:"preset" toml:"preset"`
+	// GFM enables GitHub Flavored Markdown in convert.
+	GFM bool `yaml:"gfm" toml:"gfm"`
+	// Strict makes convert exit with status 1 on diagnostics.
+	Strict bool `yaml:"strict" toml:"strict"`
+	// Document makes convert write HTML5 documents instead of fragments.
+	Document bool `yaml:"document" toml:"document"`
+	// Types lists the enabled marker types: "1", "a", "A", "i", "I".
+	Types []string `yaml:"types" toml:"types"`
+	// MixedCase is the mixed-case policy: normalize, reject or diagnose.
+	MixedCase string `yaml:"mixed-case" toml:"mixed-case"`
+	// Ambiguous is the policy for i, v and x: context, alphabetic or roman.
+	Ambiguous string `yaml:"ambiguous" toml:"ambiguous"`
+	// Padding is how zero-padded numbers are written: none, class or data.
+	Padding string `yaml:"padding" toml:"padding"`
+	// Classes selects the optional classes and replaces the class written
+	// for each list type named in Types.
+	Classes struct {
+		Delimiters bool              `yaml:"delimiters" toml:"delimiters"`
+		Bullets    bool              `yaml:"bullets" toml:"bullets"`
+		Types      map[string]string `yaml:"types" toml:"types"`
+	} `yaml:"classes" toml:"classes"`
+	// ItemValues writes a value attribute on every ordered item.
+	ItemValues bool `yaml:"item-values" toml:"item-values"`
+	// Minimal writes only the attributes sanitizers keep.
+	Minimal bool `yaml:"minimal" toml:"minimal"`
+	// Semantic writes only type, start and reversed.
+	Semantic bool `yaml:"semantic" toml:"semantic"`
+	// CommonMarkOutput renders plain lists as Goldmark's core renderer does.
+	CommonMarkOutput bool `yaml:"commonmark-output" toml:"commonmark-output"`
+	// Alphabet replaces a-z for letter markers.
+	Alphabet string `yaml:"alphabet" toml:"alphabet"`
+}
+
+// Names accepted for the enumerated settings.
+var (
+	configTypes = map[string]fancylists.MarkerType{
+		"1": fancylists.Numeric,
+		"a": fancylists.LowerAlpha,
+		"A": fancylists.UpperAlpha,
+		"i": fancylists.LowerRoman,
+		"I": fancylists.UpperRoman,
+	}
+	configMixedCase = map[string]fancylists.MixedCasePolicy{
+		"normalize": fancylists.MixedCaseNormalize,
+		"reject":    fancylists.MixedCaseReject,
+		"diagnose":  fancylists.MixedCaseDiagnose,
+	}
+	configAmbiguous = map[string]fancylists.AmbiguityPolicy{
+		"context":    fancylists.AmbiguousContext,
+		"alphabetic": fancylists.AmbiguousAlphabetic,
+		"roman":      fancylists.AmbiguousRoman,
+	}
+	configPadding = map[string]fancylists.PaddingMode{
+		"none":  fancylists.PaddingNone,
+		"class": fancylists.PaddingClass,
+		"data":  fancylists.PaddingDataAttribute,
+	}
+)
+
+// loadConfig reads the configuration file at path or, if path is empty,
+// the first of configNames found in the working directory or its parents.
+// No file found is an empty configuration.
+func loadConfig(path string) (config, error) {
+	var c config
+	if path == "" {
+		var err error
+		if path, err = findConfig(); path == "" || err != nil {
+			return c, err
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return c, err
+	}
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		md, err := toml.Decode(string(data), &c)
+		if err != nil {
+			return c, fmt.Errorf("%s: %v", path, err)
+		}
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			return c, fmt.Errorf("%s: unknown setting %q", path, undecoded[0].String())
+		}
+		return c, nil
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&c); err != nil && !errors.Is(err, io.EOF) {
+		return c, fmt.Errorf("%s: %v", path, err)
+	}
+	return c, nil
+}
+
+// findConfig returns the path of the nearest configuration file, or "".
+func findConfig() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		for _, name := range configNames {
+			path := filepath.Join(dir, name)
+			if _, err := os.Stat(path); err == nil {
+				return path, nil
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return "", err
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// override replaces the settings given as flags, among preset, gfm, strict
+// and document, with their values.
+func (c *config) override(flags *flag.FlagSet) {
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "preset":
+			c.Preset = f.Value.String()
+		case "gfm":
+			c.GFM = f.Value.(flag.Getter).Get().(bool)
+		case "strict":
+			c.Strict = f.Value.(flag.Getter).Get().(bool)
+		case "document":
+			c.Document = f.Value.(flag.Getter).Get().(bool)
+		}
+	})
+}
+
+// options returns the extension options c declares: the preset, then the
+// other settings, so settings override the preset.
+func (c *config) options() ([]fancylists.Option, error) {
+	opts, err := presetOptions(c.Preset)
+	if err != nil {
+		return nil, err
+	}
+	if len(c.Types) > 0 {
+		types := make([]fancylists.MarkerType, len(c.Types))
+		for i, name := range c.Types {
+			typ, ok := configTypes[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown marker type %q", name)
+			}
+			types[i] = typ
+		}
+		opts = append(opts, fancylists.WithTypes(types...))
+	}
+	if c.MixedCase != "" {
+		policy, ok := configMixedCase[c.MixedCase]
+		if !ok {
+			return nil, fmt.Errorf("unknown mixed-case policy %q", c.MixedCase)
+		}
+		opts = append(opts, fancylists.WithMixedCase(policy))
+	}
+	if c.Ambiguous != "" {
+		policy, ok := configAmbiguous[c.Ambiguous]
+		if !ok {
+			return nil, fmt.Errorf("unknown ambiguous policy %q", c.Ambiguous)
+		}
+		opts = append(opts, fancylists.WithAmbiguousMarkers(policy))
+	}
+	if c.Padding != "" {
+		mode, ok := configPadding[c.Padding]
+		if !ok {
+			return nil, fmt.Errorf("unknown padding mode %q", c.Padding)
+		}
+		opts = append(opts, fancylists.WithPadding(mode))
+	}
+	if c.Classes.Delimiters {
+		opts = append(opts, fancylists.WithDelimiterClasses())
+	}
+	if c.Classes.Bullets {
+		opts = append(opts, fancylists.WithBulletClasses())
+	}
+	if classes, err := c.classMap(); err != nil {
+		return nil, err
+	} else if classes != nil {
+		opts = append(opts, fancylists.WithClassMap(classes))
+	}
+	if c.ItemValues {
+		opts = append(opts, fancylists.WithItemValues())
+	}
+	if c.Minimal {
+		opts = append(opts, fancylists.WithMinimalOutput())
+	}
+	if c.Semantic {
+		opts = append(opts, fancylists.WithSemanticOutput())
+	}
+	if c.CommonMarkOutput {
+		opts = append(opts, fancylists.WithCommonMarkOutput())
+	}
+	if c.Alphabet != "" {
+		opts = append(opts, fancylists.WithAlphabet(c.Alphabet))
+	}
+	return opts, nil
+}
+
+// classMap returns the class map c declares, or nil if it declares none.
+func (c *config) classMap() (fancylists.ClassMap, error) {
+	if len(c.Classes.Types) == 0 {
+		return nil, nil
+	}
+	m := make(fancylists.ClassMap, len(c.Classes.Types))
+	for typ, class := range c.Classes.Types {
+		if _, ok := configTypes[typ]; !ok {
+			return nil, fmt.Errorf("unknown marker type %q in classes", typ)
+		}
+		m[typ] = class
+	}
+	return m, nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{
+		"team.yaml": "preset: pandoc\ngfm: true\ntypes: [1, a, I]\nmixed-case: diagnose\nclasses:\n  delimiters: true\n",
+		"team.toml": "preset = \"pandoc\"\ngfm = true\ntypes = [\"1\", \"a\", \"I\"]\nmixed-case = \"diagnose\"\n\n[classes]\ndelimiters = true\n",
+		"typo.yaml": "mixedcase: reject\n",
+		"typo.toml": "mixedcase = \"reject\"\n",
+		"bad.yaml":  "types: [b]\n",
+	})
+	for _, name := range []string{"team.yaml", "team.toml"} {
+		c, err := loadConfig(filepath.Join(dir, name))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if c.Preset != "pandoc" || !c.GFM || strings.Join(c.Types, ",") != "1,a,I" || c.MixedCase != "diagnose" || !c.Classes.Delimiters {
+			t.Errorf("%s: decoded %+v", name, c)
+		}
+		if opts, err := c.options(); err != nil || len(opts) != 4 {
+			t.Errorf("%s: %d options, error %v", name, len(opts), err)
+		}
+	}
+	for _, name := range []string{"typo.yaml", "typo.toml"} {
+		if _, err := loadConfig(filepath.Join(dir, name)); err == nil || !strings.Contains(err.Error(), "mixedcase") {
+			t.Errorf("%s: error %v, want one naming the unknown setting", name, err)
+		}
+	}
+	c, err := loadConfig(filepath.Join(dir, "bad.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.options(); err == nil {
+		t.Error("unknown marker type accepted")
+	}
+}
+
+func TestConfigClassMap(t *testing.T) {
+	var c config
+	c.Classes.Types = map[string]string{"a": "letters"}
+	opts, err := c.options()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := newMarkdown(opts, false).Convert([]byte("a. one\n"), &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `class="fancy letters"`) {
+		t.Errorf("output %q does not use the configured class", buf.String())
+	}
+	c.Classes.Types = map[string]string{"b": "letters"}
+	if _, err := c.options(); err == nil {
+		t.Error("unknown marker type in classes accepted")
+	}
+}
+
+func TestConfigOverride(t *testing.T) {
+	c := config{Preset: "pandoc", GFM: true}
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.String("preset", "", "")
+	flags.Bool("gfm", false, "")
+	flags.Bool("strict", false, "")
+	if err := flags.Parse([]string{"-gfm=false", "-strict"}); err != nil {
+		t.Fatal(err)
+	}
+	c.override(flags)
+	if c.Preset != "pandoc" || c.GFM || !c.Strict {
+		t.Errorf("overridden config %+v", c)
+	}
+}
+
+func TestConvertFindsConfig(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{
+		".fancylists.yaml": "types: [1, I]\nstrict: true\nmixed-case: diagnose\n",
+		"sub/.keep":        "",
+	})
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(filepath.Join(dir, "sub")); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(wd) }()
+
+	var stdout, stderr bytes.Buffer
+	if status := run([]string{"convert", "-"}, strings.NewReader("a. one\n"), &stdout, &stderr); status != exitSame {
+		t.Errorf("status %d, errors %q", status, stderr.String())
+	}
+	if want := "<p>a. one</p>\n"; stdout.String() != want {
+		t.Errorf("output %q, want %q: types from the configuration were not applied", stdout.String(), want)
+	}
+
+	if status := run([]string{"convert", "-"}, strings.NewReader("Ii. one\n"), &stdout, &stderr); status != exitDiagnostics {
+		t.Errorf("strict from the configuration: status %d, want %d", status, exitDiagnostics)
+	}
+	if status := run([]string{"convert", "-strict=false", "-"}, strings.NewReader("Ii. one\n"), &stdout, &stderr); status != exitSame {
+		t.Errorf("-strict=false: status %d, want %d", status, exitSame)
+	}
+}
@@ -0,0 +1,348 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+
+	fancylists "github.com/zmtcreative/gm-fancy-lists"
+)
+
+// markdownExtensions are the file extensions convert reads when given a
+// directory, and replaces with ".html" when naming output files.
+var markdownExtensions = []string{".md", ".markdown"}
+
+// stdinName names standard input in diagnostics.
+const stdinName = "<stdin>"
+
+// conversion is one file for convert to render.
+type conversion struct {
+	src, dst string
+}
+
+// converter renders Markdown for convert.
+type converter struct {
+	md goldmark.Markdown
+	// document wraps each output in an HTML5 document styled with css.
+	document bool
+	css      string
+}
+
+// result is the outcome of one conversion.
+type result struct {
+	diagnostics []fancylists.Diagnostic
+	err         error
+}
+
+// runConvert implements the convert command.
+func runConvert(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("convert", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	out := flags.String("o", "", "output `directory`; by default each HTML file is written next to its source")
+	jobs := flags.Int("j", runtime.NumCPU(), "number of files to convert in parallel")
+	configPath := flags.String("config", "", "configuration `file` (default the nearest "+strings.Join(configNames, ", ")+")")
+	flags.String("preset", "", "extension `preset`: pandoc, commonmark or html5")
+	flags.Bool("gfm", false, "enable GitHub Flavored Markdown (tables, strikethrough, task lists, autolinks)")
+	flags.Bool("strict", false, "exit with status 1 when the parser reports diagnostics, such as mixed-case markers")
+	flags.Bool("document", false, "write a minimal HTML5 document with the extension's stylesheet instead of a fragment")
+	title := flags.String("title", "", "`title` of documents written with -document (default the file name)")
+	flags.Usage = func() {
+		fmt.Fprint(stderr, "usage: fancylists convert [flags] pattern ...\n"+
+			"       fancylists convert [flags] -\n\n"+
+			"Renders Markdown files to HTML. A pattern is a file, a directory, whose\n"+
+			"Markdown files are all converted, or a glob in which ** matches any\n"+
+			"number of directories, as in 'docs/**/*.md'. Output paths keep the\n"+
+			"layout below the pattern's leading directory. The pattern - converts\n"+
+			"standard input to standard output.\n\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitSame
+		}
+		return exitError
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return exitError
+	}
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "fancylists: %v\n", err)
+		return exitError
+	}
+	cfg.override(flags)
+	opts, err := cfg.options()
+	if err != nil {
+		fmt.Fprintf(stderr, "fancylists: %v\n", err)
+		return exitError
+	}
+	if cfg.Strict {
+		// Placed first so that a preset's own mixed-case policy wins.
+		opts = append([]fancylists.Option{fancylists.WithMixedCase(fancylists.MixedCaseDiagnose)}, opts...)
+	}
+	c := &converter{md: newMarkdown(opts, cfg.GFM), document: cfg.Document}
+	if c.document {
+		classes, _ := cfg.classMap()
+		c.css = fancylists.Stylesheet(classes) + fancylists.CounterStyles(cfg.Alphabet, classes)
+	}
+
+	if flags.Arg(0) == "-" {
+		if flags.NArg() > 1 {
+			fmt.Fprintln(stderr, "fancylists: - cannot be combined with other patterns")
+			return exitError
+		}
+		source, err := io.ReadAll(stdin)
+		if err != nil {
+			fmt.Fprintf(stderr, "fancylists: %s: %v\n", stdinName, err)
+			return exitError
+		}
+		name := *title
+		if name == "" {
+			name = "fancylists"
+		}
+		html, diagnostics, err := c.render(source, name)
+		if err == nil {
+			_, err = stdout.Write(html)
+		}
+		return report(stderr, []string{stdinName}, []result{{diagnostics, err}}, cfg.Strict)
+	}
+
+	var files []conversion
+	seen := map[string]bool{}
+	for _, pattern := range flags.Args() {
+		base, matches, err := expandPattern(pattern)
+		if err != nil {
+			fmt.Fprintf(stderr, "fancylists: %s: %v\n", pattern, err)
+			return exitError
+		}
+		if len(matches) == 0 {
+			fmt.Fprintf(stderr, "fancylists: %s: no Markdown files match\n", pattern)
+			return exitError
+		}
+		for _, src := range matches {
+			if seen[src] {
+				continue
+			}
+			seen[src] = true
+			files = append(files, conversion{src: src, dst: outputPath(base, src, *out)})
+		}
+	}
+
+	results := make([]result, len(files))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < max(*jobs, 1); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				diagnostics, err := c.convertFile(files[i].src, files[i].dst, *title)
+				results[i] = result{diagnostics, err}
+			}
+		}()
+	}
+	for i := range files {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	names := make([]string, len(files))
+	for i, f := range files {
+		names[i] = f.src
+	}
+	return report(stderr, names, results, cfg.Strict)
+}
+
+// report writes the diagnostics and errors of each named conversion to
+// stderr, in order, and returns the exit status: exitError if any
+// conversion failed, else exitDiagnostics if strict and any conversion
+// reported diagnostics.
+func report(stderr io.Writer, names []string, results []result, strict bool) int {
+	status := exitSame
+	for i, r := range results {
+		for _, d := range r.diagnostics {
+			fmt.Fprintf(stderr, "%s:%d: %q: %s\n", names[i], d.Line, d.Marker, d.Message)
+			if strict && status == exitSame {
+				status = exitDiagnostics
+			}
+		}
+		if r.err != nil {
+			fmt.Fprintf(stderr, "fancylists: %s: %v\n", names[i], r.err)
+			status = exitError
+		}
+	}
+	return status
+}
+
+// newMarkdown returns the Goldmark instance convert renders with.
+func newMarkdown(opts []fancylists.Option, gfm bool) goldmark.Markdown {
+	extensions := []goldmark.Extender{fancylists.NewFancyLists(opts...)}
+	if gfm {
+		extensions = append(extensions, extension.GFM)
+	}
+	return goldmark.New(goldmark.WithExtensions(extensions...))
+}
+
+// render converts source and returns the HTML, a document titled title if
+// c.document is set, and the diagnostics the parser recorded.
+func (c *converter) render(source []byte, title string) ([]byte, []fancylists.Diagnostic, error) {
+	pc := parser.NewContext()
+	var buf bytes.Buffer
+	if c.document {
+		writeDocumentStart(&buf, title, c.css)
+	}
+	if err := c.md.Convert(source, &buf, parser.WithContext(pc)); err != nil {
+		return nil, nil, err
+	}
+	if c.document {
+		writeDocumentEnd(&buf)
+	}
+	return buf.Bytes(), fancylists.Diagnostics(pc), nil
+}
+
+// convertFile renders the Markdown file src to the HTML file dst, creating
+// its directory if needed, and returns the diagnostics of the conversion.
+// Documents are titled title, or the base name of src if title is empty.
+func (c *converter) convertFile(src, dst, title string) ([]fancylists.Diagnostic, error) {
+	source, err := os.ReadFile(src)
+	if err != nil {
+		return nil, err
+	}
+	if title == "" {
+		title = strings.TrimSuffix(filepath.Base(src), filepath.Ext(src))
+	}
+	html, diagnostics, err := c.render(source, title)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return diagnostics, err
+	}
+	return diagnostics, os.WriteFile(dst, html, 0o644)
+}
+
+// outputPath returns where convert writes the HTML for src, matched by a
+// pattern whose leading directory is base: below out at the same path
+// relative to base, or next to src when out is empty.
+func outputPath(base, src, out string) string {
+	dst := src
+	if out != "" {
+		rel, err := filepath.Rel(base, src)
+		if err != nil {
+			rel = filepath.Base(src)
+		}
+		dst = filepath.Join(out, rel)
+	}
+	if isMarkdownFile(dst) {
+		return strings.TrimSuffix(dst, filepath.Ext(dst)) + ".html"
+	}
+	return dst + ".html"
+}
+
+// expandPattern returns the files pattern names, in lexical order, and the
+// leading directory of the pattern that output paths are relative to. A
+// directory names every Markdown file below it; a glob is matched segment
+// by segment with path.Match, where a "**" segment matches any number of
+// directories.
+func expandPattern(pattern string) (base string, files []string, err error) {
+	if info, err := os.Stat(pattern); err == nil {
+		if !info.IsDir() {
+			return filepath.Dir(pattern), []string{pattern}, nil
+		}
+		files, err := walkFiles(pattern, isMarkdownFile)
+		return pattern, files, err
+	}
+
+	segments := strings.Split(filepath.ToSlash(pattern), "/")
+	k := 0
+	for ; k < len(segments)-1 && !strings.ContainsAny(segments[k], `*?[\`); k++ {
+	}
+	for _, segment := range segments[k:] {
+		if _, err := path.Match(segment, ""); err != nil {
+			return "", nil, err
+		}
+	}
+	base = filepath.FromSlash(strings.Join(segments[:k], "/"))
+	switch {
+	case k == 0:
+		base = "."
+	case base == "":
+		base = string(filepath.Separator)
+	}
+	if _, err := os.Stat(base); errors.Is(err, fs.ErrNotExist) {
+		return base, nil, nil
+	}
+	files, err = walkFiles(base, func(rel string) bool {
+		return matchSegments(segments[k:], strings.Split(filepath.ToSlash(rel), "/"))
+	})
+	return base, files, err
+}
+
+// walkFiles returns the regular files below dir whose path relative to dir
+// satisfies match.
+func walkFiles(dir string, match func(rel string) bool) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if match(rel) {
+			files = append(files, p)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// isMarkdownFile reports whether name has one of the Markdown extensions.
+func isMarkdownFile(name string) bool {
+	for _, ext := range markdownExtensions {
+		if strings.EqualFold(filepath.Ext(name), ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments reports whether the path segments name match the glob
+// segments pattern, where "**" matches zero or more segments.
+func matchSegments(pattern, name []string) bool {
+	if len(pattern) == 0 {
+		return len(name) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(name); i++ {
+			if matchSegments(pattern[1:], name[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(name) == 0 {
+		return false
+	}
+	ok, _ := path.Match(pattern[0], name[0])
+	return ok && matchSegments(pattern[1:], name[1:])
+}
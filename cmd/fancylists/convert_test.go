@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTree creates files, keyed by slash-separated path, below dir.
+func writeTree(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestMatchSegments(t *testing.T) {
+	for _, c := range []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"*.md", "a.md", true},
+		{"*.md", "sub/a.md", false},
+		{"**/*.md", "a.md", true},
+		{"**/*.md", "sub/deep/a.md", true},
+		{"sub/**/a.md", "sub/a.md", true},
+		{"sub/**/a.md", "other/a.md", false},
+		{"**", "sub/a.txt", true},
+	} {
+		got := matchSegments(strings.Split(c.pattern, "/"), strings.Split(c.name, "/"))
+		if got != c.want {
+			t.Errorf("matchSegments(%q, %q) = %v, want %v", c.pattern, c.name, got, c.want)
+		}
+	}
+}
+
+func TestConvertGlob(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{
+		"docs/index.md":         "a. one\nb. two\n",
+		"docs/guide/steps.md":   "i. first\nii. second\n",
+		"docs/guide/notes.txt":  "not converted",
+		"docs/deep/er/intro.md": "- item\n",
+	})
+	out := filepath.Join(dir, "out")
+	var stdout, stderr bytes.Buffer
+	pattern := filepath.Join(dir, "docs", "**", "*.md")
+	if status := run([]string{"convert", "-o", out, "-j", "2", pattern}, nil, &stdout, &stderr); status != exitSame {
+		t.Fatalf("status %d, errors %q", status, stderr.String())
+	}
+	for name, want := range map[string]string{
+		"index.html":         `<ol class="fancy fl-lcalpha" type="a" start="1">`,
+		"guide/steps.html":   `<ol class="fancy fl-lcroman" type="i" start="1">`,
+		"deep/er/intro.html": "<ul>",
+	} {
+		got, err := os.ReadFile(filepath.Join(out, filepath.FromSlash(name)))
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if !strings.Contains(string(got), want) {
+			t.Errorf("%s does not contain %q:\n%s", name, want, got)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(out, "guide", "notes.html")); err == nil {
+		t.Error("converted a file the pattern does not match")
+	}
+}
+
+func TestConvertDirectoryInPlace(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{
+		"a.md":           "1. one\n",
+		"sub/b.markdown": "A) one\n",
+		"sub/c.txt":      "skipped",
+	})
+	var stdout, stderr bytes.Buffer
+	if status := run([]string{"convert", dir}, nil, &stdout, &stderr); status != exitSame {
+		t.Fatalf("status %d, errors %q", status, stderr.String())
+	}
+	for _, name := range []string{"a.html", "sub/b.html"} {
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(name))); err != nil {
+			t.Error(err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "sub", "c.html")); err == nil {
+		t.Error("converted a file without a Markdown extension")
+	}
+}
+
+func TestConvertReportsEachFailure(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{
+		"a.md": "a. one\n",
+		"b.md": "b. two\n",
+	})
+	// A directory where b's output belongs makes only b fail.
+	out := filepath.Join(dir, "out")
+	if err := os.MkdirAll(filepath.Join(out, "b.html"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	status := run([]string{"convert", "-o", out, filepath.Join(dir, "*.md")}, nil, &stdout, &stderr)
+	if status != exitError {
+		t.Errorf("status %d, want %d", status, exitError)
+	}
+	if !strings.Contains(stderr.String(), "b.md: ") || strings.Contains(stderr.String(), "a.md") {
+		t.Errorf("errors %q should name b.md only", stderr.String())
+	}
+	if _, err := os.Stat(filepath.Join(out, "a.html")); err != nil {
+		t.Errorf("a.md was not converted: %v", err)
+	}
+}
+
+func TestConvertNoMatch(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	status := run([]string{"convert", filepath.Join(t.TempDir(), "**", "*.md")}, nil, &stdout, &stderr)
+	if status != exitError || !strings.Contains(stderr.String(), "no Markdown files match") {
+		t.Errorf("status %d, errors %q", status, stderr.String())
+	}
+}
+
+func TestConvertStdin(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	status := run([]string{"convert", "-"}, strings.NewReader("a. one\nb. two\n"), &stdout, &stderr)
+	if status != exitSame || stderr.Len() != 0 {
+		t.Fatalf("status %d, errors %q", status, stderr.String())
+	}
+	want := "<ol class=\"fancy fl-lcalpha\" type=\"a\" start=\"1\">\n<li>one</li>\n<li>two</li>\n</ol>\n"
+	if stdout.String() != want {
+		t.Errorf("output %q, want %q", stdout.String(), want)
+	}
+
+	status = run([]string{"convert", "-", "a.md"}, strings.NewReader(""), &stdout, &stderr)
+	if status != exitError {
+		t.Errorf("- with other patterns: status %d, want %d", status, exitError)
+	}
+}
+
+func TestConvertStrict(t *testing.T) {
+	const mixed = "Ii. First\nIII. Second\n"
+	var stdout, stderr bytes.Buffer
+	if status := run([]string{"convert", "-"}, strings.NewReader(mixed), &stdout, &stderr); status != exitSame {
+		t.Errorf("without -strict: status %d, want %d", status, exitSame)
+	}
+
+	stdout.Reset()
+	status := run([]string{"convert", "-strict", "-"}, strings.NewReader(mixed), &stdout, &stderr)
+	if status != exitDiagnostics {
+		t.Errorf("-strict: status %d, want %d", status, exitDiagnostics)
+	}
+	if !strings.HasPrefix(stderr.String(), `<stdin>:1: "Ii": marker mixes upper and lower case`) {
+		t.Errorf("-strict: errors %q", stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "<ol") {
+		t.Errorf("-strict: output %q should still hold the HTML", stdout.String())
+	}
+
+	stderr.Reset()
+	status = run([]string{"convert", "-strict", "-"}, strings.NewReader("1. one\n"), &stdout, &stderr)
+	if status != exitSame || stderr.Len() != 0 {
+		t.Errorf("-strict without diagnostics: status %d, errors %q", status, stderr.String())
+	}
+}
+
+func TestConvertDocument(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	status := run([]string{"convert", "-document", "-title", "Steps & notes", "-"}, strings.NewReader("a. one\n"), &stdout, &stderr)
+	if status != exitSame {
+		t.Fatalf("status %d, errors %q", status, stderr.String())
+	}
+	got := stdout.String()
+	for _, want := range []string{
+		"<!DOCTYPE html>\n",
+		"<title>Steps &amp; notes</title>",
+		"ol.fl-lcalpha { list-style-type: lower-alpha; }",
+		"<body>\n<ol class=\"fancy fl-lcalpha\" type=\"a\" start=\"1\">",
+		"</ol>\n</body>\n</html>\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("document does not contain %q:\n%s", want, got)
+		}
+	}
+
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{"guide.md": "1. one\n"})
+	if status := run([]string{"convert", "-document", dir}, nil, &stdout, &stderr); status != exitSame {
+		t.Fatalf("status %d, errors %q", status, stderr.String())
+	}
+	html, err := os.ReadFile(filepath.Join(dir, "guide.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(html), "<title>guide</title>") {
+		t.Errorf("document is not titled after its file:\n%s", html)
+	}
+}
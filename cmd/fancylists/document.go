@@ -0,0 +1,21 @@
+package main
+
+import (
+	"bytes"
+	"html"
+)
+
+// writeDocumentStart writes the start of a minimal HTML5 document titled
+// title, with css in its head, up to the opening of the body that the
+// converted fragment fills.
+func writeDocumentStart(buf *bytes.Buffer, title, css string) {
+	buf.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	buf.WriteString("<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n")
+	buf.WriteString("<title>" + html.EscapeString(title) + "</title>\n")
+	buf.WriteString("<style>\n" + css + "</style>\n</head>\n<body>\n")
+}
+
+// writeDocumentEnd closes a document begun with writeDocumentStart.
+func writeDocumentEnd(buf *bytes.Buffer) {
+	buf.WriteString("</body>\n</html>\n")
+}
@@ -0,0 +1,12 @@
+module github.com/zmtcreative/gm-fancy-lists/cmd/fancylists
+
+go 1.22
+
+require (
+	github.com/BurntSushi/toml v1.5.0
+	github.com/yuin/goldmark v1.7.13
+	github.com/zmtcreative/gm-fancy-lists v0.3.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require github.com/brandenc40/romannumeral v1.1.5 // indirect
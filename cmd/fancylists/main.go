@@ -0,0 +1,91 @@
+// Command fancylists converts Markdown documents with the fancylists
+// extension and checks them against other implementations.
+//
+// Usage:
+//
+//	fancylists convert [flags] [pattern ...]
+//	fancylists compare [flags] [file ...]
+//
+// The convert command renders Markdown files to HTML. Patterns are file
+// names, directories, or globs in which "**" matches any number of
+// directories, such as 'docs/**/*.md'; files are converted in parallel and
+// a failure in one is reported without stopping the others. The pattern "-"
+// converts standard input to standard output. Diagnostics the parser
+// records are written to standard error; with -strict the command exits
+// with status 1 when there are any.
+//
+// The compare command parses each file, or standard input when no file is
+// given, and reports where the extension's lists differ from those of
+// another implementation: Goldmark's core parser by default, or a local
+// Pandoc with -pandoc. It exits with status 1 when any file differs.
+//
+// Both commands exit with status 2 on errors.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	fancylists "github.com/zmtcreative/gm-fancy-lists"
+)
+
+// Exit statuses, following diff(1).
+const (
+	exitSame        = 0
+	exitDiffer      = 1 // compare found differences
+	exitDiagnostics = 1 // convert -strict recorded diagnostics
+	exitError       = 2
+)
+
+const usage = `usage: fancylists <command> [flags] [file ...]
+
+Commands:
+  convert   render Markdown files to HTML
+  compare   report where the extension's lists differ from core Goldmark or Pandoc
+
+Run "fancylists <command> -h" for the flags of a command.
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command named by args[0] and returns the exit status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitError
+	}
+	switch args[0] {
+	case "convert":
+		return runConvert(args[1:], stdin, stdout, stderr)
+	case "compare":
+		return runCompare(args[1:], stdin, stdout, stderr)
+	case "-h", "-help", "--help", "help":
+		fmt.Fprint(stdout, usage)
+		return exitSame
+	}
+	fmt.Fprintf(stderr, "fancylists: unknown command %q\n\n%s", args[0], usage)
+	return exitError
+}
+
+// presets maps the names accepted by -preset to the extension's presets.
+var presets = map[string]fancylists.Option{
+	"pandoc":     fancylists.PandocCompat(),
+	"commonmark": fancylists.CommonMarkStrict(),
+	"html5":      fancylists.HTML5Semantic(),
+}
+
+// presetOptions returns the extension options for the preset named by
+// -preset, none for "".
+func presetOptions(name string) ([]fancylists.Option, error) {
+	if name == "" {
+		return nil, nil
+	}
+	opt, ok := presets[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown preset %q", name)
+	}
+	return []fancylists.Option{opt}, nil
+}
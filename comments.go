@@ -0,0 +1,75 @@
+package fancylists
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+// commentContinuationTransformer merges lists separated only by HTML
+// comments (see WithCommentContinuation).
+type commentContinuationTransformer struct{}
+
+func (t *commentContinuationTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		list, ok := n.(*ast.List)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+		for {
+			var comments []ast.Node
+			next := list.NextSibling()
+			for ; isCommentBlock(next); next = next.NextSibling() {
+				comments = append(comments, next)
+			}
+			following, ok := next.(*ast.List)
+			if len(comments) == 0 || !ok || !sameListType(list, following) {
+				break
+			}
+			mergeLists(list, following, comments)
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+// isCommentBlock reports whether n is an HTML block holding a comment.
+func isCommentBlock(n ast.Node) bool {
+	block, ok := n.(*ast.HTMLBlock)
+	return ok && block.HTMLBlockType == ast.HTMLBlockType2
+}
+
+// sameListType reports whether b can continue list a: both have the same
+// marker, type, prefix and suffix, and neither counts down.
+func sameListType(a, b *ast.List) bool {
+	return a.Marker == b.Marker && listType(a) == listType(b) &&
+		bytes.Equal(listPrefix(a), listPrefix(b)) && bytes.Equal(listSuffix(a), listSuffix(b)) &&
+		!isReversed(a) && !isReversed(b)
+}
+
+// mergeLists moves the comments into the last item of list and the items
+// of following after them, numbering those items on from list's last item
+// unless their value was set explicitly. following is removed.
+func mergeLists(list, following *ast.List, comments []ast.Node) {
+	last := list.LastChild()
+	for _, comment := range comments {
+		comment.Parent().RemoveChild(comment.Parent(), comment)
+		last.AppendChild(last, comment)
+	}
+	value := itemValue(last)
+	for item := following.FirstChild(); item != nil; {
+		next := item.NextSibling()
+		if _, ok := item.Attribute(attrNameValue); ok {
+			value++
+			if _, explicit := item.Attribute(attrNameExplicit); explicit {
+				value = itemValue(item)
+			}
+			item.SetAttribute(attrNameValue, value)
+		}
+		list.AppendChild(list, item)
+		item = next
+	}
+	list.IsTight = list.IsTight && following.IsTight
+	following.Parent().RemoveChild(following.Parent(), following)
+}
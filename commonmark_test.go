@@ -0,0 +1,56 @@
+package fancylists
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/renderer/html"
+)
+
+// commonMarkCase is one example from the CommonMark specification.
+type commonMarkCase struct {
+	Example  int    `json:"example"`
+	Section  string `json:"section"`
+	Markdown string `json:"markdown"`
+	HTML     string `json:"html"`
+}
+
+// TestCommonMarkConformance runs the "List items" and "Lists" examples of
+// the CommonMark specification (testdata/commonmark_lists.json, taken from
+// Goldmark's copy of the spec, which is licensed under CC BY-SA 4.0) and
+// checks that WithCommonMarkOutput renders them exactly as the
+// specification and Goldmark's core list parser do.
+func TestCommonMarkConformance(t *testing.T) {
+	data, err := os.ReadFile("testdata/commonmark_lists.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cases []commonMarkCase
+	if err := json.Unmarshal(data, &cases); err != nil {
+		t.Fatal(err)
+	}
+
+	// The specification expects raw HTML to be passed through
+	unsafe := goldmark.WithRendererOptions(html.WithUnsafe())
+	reference := goldmark.New(unsafe)
+	md := goldmark.New(unsafe, goldmark.WithExtensions(NewFancyLists(WithCommonMarkOutput())))
+	for _, c := range cases {
+		var want, got bytes.Buffer
+		if err := reference.Convert([]byte(c.Markdown), &want); err != nil {
+			t.Fatal(err)
+		}
+		if err := md.Convert([]byte(c.Markdown), &got); err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != want.String() {
+			t.Errorf("example %d (%s) differs from the core list parser:\nmarkdown:\n%s\ngot:\n%s\nwant:\n%s",
+				c.Example, c.Section, c.Markdown, got.String(), want.String())
+		} else if got.String() != c.HTML {
+			t.Errorf("example %d (%s) differs from the specification:\nmarkdown:\n%s\ngot:\n%s\nwant:\n%s",
+				c.Example, c.Section, c.Markdown, got.String(), c.HTML)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package fancylists
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/yuin/goldmark"
+)
+
+// TestConcurrentConversions registers one configured extension value into
+// several goldmark instances and converts from many goroutines at once.
+// Run with -race to check that no mutable state is shared between them.
+func TestConcurrentConversions(t *testing.T) {
+	ext := NewFancyLists(WithCompact())
+	instances := []goldmark.Markdown{
+		goldmark.New(goldmark.WithExtensions(ext)),
+		goldmark.New(goldmark.WithExtensions(ext)),
+	}
+	source := []byte("a. First\nb. Second\n   i. Nested\n   #. Nested\n\n1. One\n2. Two\n")
+
+	var want bytes.Buffer
+	if err := instances[0].Convert(source, &want); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		md := instances[g%len(instances)]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				var out bytes.Buffer
+				if err := md.Convert(source, &out); err != nil {
+					t.Error(err)
+					return
+				}
+				if out.String() != want.String() {
+					t.Errorf("concurrent output differs:\n%s\nwant:\n%s", out.String(), want.String())
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// TestOptionsCopiedOnExtend checks that changing an options value after it
+// has been registered does not affect instances already created with it.
+func TestOptionsCopiedOnExtend(t *testing.T) {
+	ext := NewFancyLists()
+	md := goldmark.New(goldmark.WithExtensions(ext))
+	ext.Compact = true
+
+	var out bytes.Buffer
+	if err := md.Convert([]byte("a. One\nb. Two\n"), &out); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(out.Bytes(), []byte("<li>One</li>\n")) {
+		t.Errorf("option change after Extend leaked into instance:\n%s", out.String())
+	}
+}
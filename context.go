@@ -0,0 +1,116 @@
+package fancylists
+
+import (
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+)
+
+// Cooperating extensions coordinate with this one through the
+// parser.Context of a conversion rather than through its context keys,
+// which stay private: Active tells them the extension takes part in the
+// conversion, Disable and Enable switch its parsers off and on around
+// blocks that must keep CommonMark list syntax, and ParsedLists reads the
+// lists it has built so far.
+
+// stateKey holds the *conversionState of a conversion.
+var stateKey = parser.NewContextKey()
+
+// attrNameCore marks the lists Goldmark's core parser opened in a
+// conversion where Disable was used, so they render as core lists.
+var attrNameCore = []byte("fl-core")
+
+// conversionState is the state the parsers and transformers share during
+// one conversion. It lives in the parser.Context, so parsers hold no state
+// of their own and any number of conversions may run at once.
+type conversionState struct {
+	disabled bool
+	// everDisabled records that Disable was called during the conversion.
+	everDisabled bool
+	// lists are the lists the list parser opened, in order.
+	lists []*ast.List
+	// typedLists are the lists whose computed type listTypeTransformer
+	// copies to their type attribute.
+	typedLists []*ast.List
+
+	// skipListParser is set by the list item parser when it closes an item
+	// at a line starting the next item, so the list parser, offered the
+	// same line, leaves it to the item parser.
+	skipListParser bool
+	// emptyItemBlankLine is set when a blank line follows an empty item: a
+	// list item can begin with at most one blank line, so the next line
+	// that is not an item ends the list.
+	emptyItemBlankLine bool
+}
+
+// stateOf returns the state of the conversion using pc, creating it on
+// first use.
+func stateOf(pc parser.Context) *conversionState {
+	if s, ok := pc.Get(stateKey).(*conversionState); ok {
+		return s
+	}
+	s := &conversionState{}
+	pc.Set(stateKey, s)
+	return s
+}
+
+// Active reports whether the extension takes part in the conversion using
+// pc. It is true from the first line the list parsers are offered, and
+// after parsing for every document, so block parsers can call it once
+// earlier blocks have been read and AST transformers can always rely on it.
+func Active(pc parser.Context) bool {
+	_, ok := pc.Get(stateKey).(*conversionState)
+	return ok
+}
+
+// Disable stops the extension's parsers from opening lists and list items
+// in the conversion using pc until Enable is called, leaving list markers
+// to Goldmark's core list parsers. A cooperating block parser typically
+// calls it when it opens a container whose content must keep CommonMark
+// list syntax and Enable when the container closes.
+func Disable(pc parser.Context) {
+	s := stateOf(pc)
+	s.disabled = true
+	s.everDisabled = true
+}
+
+// Enable undoes Disable.
+func Enable(pc parser.Context) {
+	stateOf(pc).disabled = false
+}
+
+// Disabled reports whether Disable is in effect for the conversion using
+// pc.
+func Disabled(pc parser.Context) bool {
+	s, ok := pc.Get(stateKey).(*conversionState)
+	return ok && s.disabled
+}
+
+// ParsedLists returns the lists the extension has opened so far in the
+// conversion using pc, in the order they were opened. Lists later merged
+// into another, as WithCommentContinuation does, remain in the result but
+// are no longer part of the document.
+func ParsedLists(pc parser.Context) []*ast.List {
+	if s, ok := pc.Get(stateKey).(*conversionState); ok {
+		return s.lists
+	}
+	return nil
+}
+
+// markCoreLists sets attrNameCore on the lists in doc the extension did not
+// open, if Disable was used in the conversion: those the core parser opened
+// while the extension was disabled.
+func markCoreLists(doc *ast.Document, s *conversionState) {
+	if !s.everDisabled {
+		return
+	}
+	ours := make(map[*ast.List]bool, len(s.lists))
+	for _, list := range s.lists {
+		ours[list] = true
+	}
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if list, ok := n.(*ast.List); ok && entering && !ours[list] {
+			list.SetAttribute(attrNameCore, listItemFlagValue)
+		}
+		return ast.WalkContinue, nil
+	})
+}
@@ -0,0 +1,146 @@
+package fancylists
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// coreBlockParser is a cooperating extension's container, "!!!" to "!!!",
+// whose content keeps CommonMark list syntax.
+type coreBlockParser struct{}
+
+func (b *coreBlockParser) Trigger() []byte { return []byte{'!'} }
+
+func (b *coreBlockParser) Open(parent ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	line, _ := reader.PeekLine()
+	if !bytes.HasPrefix(line, []byte("!!!")) {
+		return nil, parser.NoChildren
+	}
+	reader.AdvanceToEOL()
+	Disable(pc)
+	return ast.NewBlockquote(), parser.HasChildren
+}
+
+func (b *coreBlockParser) Continue(node ast.Node, reader text.Reader, pc parser.Context) parser.State {
+	line, _ := reader.PeekLine()
+	if bytes.HasPrefix(line, []byte("!!!")) {
+		reader.AdvanceToEOL()
+		return parser.Close
+	}
+	return parser.Continue | parser.HasChildren
+}
+
+func (b *coreBlockParser) Close(node ast.Node, reader text.Reader, pc parser.Context) { Enable(pc) }
+
+func (b *coreBlockParser) CanInterruptParagraph() bool { return true }
+
+func (b *coreBlockParser) CanAcceptIndentedLine() bool { return false }
+
+func TestActive(t *testing.T) {
+	for _, c := range []struct {
+		md     goldmark.Markdown
+		source string
+		want   bool
+	}{
+		{goldmark.New(goldmark.WithExtensions(FancyLists)), "a. one\n", true},
+		{goldmark.New(goldmark.WithExtensions(FancyLists)), "No lists here.\n", true},
+		{goldmark.New(), "a. one\n", false},
+	} {
+		pc := parser.NewContext()
+		if Active(pc) {
+			t.Fatal("Active before the conversion")
+		}
+		var out bytes.Buffer
+		if err := c.md.Convert([]byte(c.source), &out, parser.WithContext(pc)); err != nil {
+			t.Fatal(err)
+		}
+		if got := Active(pc); got != c.want {
+			t.Errorf("Active after converting %q = %v, want %v", c.source, got, c.want)
+		}
+	}
+}
+
+func TestDisable(t *testing.T) {
+	md := goldmark.New(
+		goldmark.WithExtensions(FancyLists),
+		goldmark.WithParserOptions(parser.WithBlockParsers(util.Prioritized(&coreBlockParser{}, 50))),
+	)
+	source := "a. fancy\n\n!!!\na. not a list\n\n1. core\n!!!\n\nb. fancy again\n"
+	pc := parser.NewContext()
+	var out bytes.Buffer
+	if err := md.Convert([]byte(source), &out, parser.WithContext(pc)); err != nil {
+		t.Fatal(err)
+	}
+	want := `<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>fancy</li>
+</ol>
+<blockquote>
+<p>a. not a list</p>
+<ol>
+<li>core</li>
+</ol>
+</blockquote>
+<ol class="fancy fl-lcalpha" type="a" start="2">
+<li>fancy again</li>
+</ol>
+`
+	if out.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out.String(), want)
+	}
+	if Disabled(pc) {
+		t.Error("still disabled after the container closed")
+	}
+	if got := len(ParsedLists(pc)); got != 2 {
+		t.Errorf("ParsedLists has %d lists, want the 2 fancy lists", got)
+	}
+}
+
+func TestParsedLists(t *testing.T) {
+	pc := parser.NewContext()
+	if ParsedLists(pc) != nil {
+		t.Fatal("ParsedLists before the conversion")
+	}
+	source := []byte("i. one\n\n   - nested\n\nB) two\n")
+	doc := mdBasic.Parser().Parse(text.NewReader(source), parser.WithContext(pc))
+	lists := ParsedLists(pc)
+	if len(lists) != 3 {
+		t.Fatalf("got %d lists, want 3", len(lists))
+	}
+	if lists[0].Parent() != doc || lists[1].Parent().Parent() != lists[0] || lists[2].Parent() != doc {
+		t.Error("lists are not the document's, in the order they were opened")
+	}
+	if listType(lists[0]) != "i" || lists[1].IsOrdered() || listType(lists[2]) != "A" {
+		t.Errorf("got types %s, %v, %s", listType(lists[0]), lists[1].IsOrdered(), listType(lists[2]))
+	}
+}
+
+func TestListParserState(t *testing.T) {
+	lists := &fancyListParser{FancyListsOptions{}}
+	open := func(pc parser.Context) ast.Node {
+		doc := ast.NewDocument()
+		pc.SetOpenedBlocks([]parser.Block{{Node: doc}})
+		node, _ := lists.Open(doc, text.NewReader([]byte("b. two\n")), pc)
+		return node
+	}
+
+	pc, other := parser.NewContext(), parser.NewContext()
+	stateOf(pc).skipListParser = true
+	if open(pc) != nil {
+		t.Error("list parser opened a list at a line left to the item parser")
+	}
+	if stateOf(pc).skipListParser {
+		t.Error("skipListParser was not cleared")
+	}
+	if open(pc) == nil {
+		t.Error("list parser did not open a list once the line was handled")
+	}
+	if open(other) == nil || len(ParsedLists(other)) != 1 || len(ParsedLists(pc)) != 1 {
+		t.Error("conversions share parser state")
+	}
+}
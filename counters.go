@@ -0,0 +1,71 @@
+package fancylists
+
+import (
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+)
+
+// Counter is the numbering state of a top-level ordered list: its type
+// ("1", "a", "A", "i" or "I") and the value of the item that would follow
+// its last item.
+type Counter struct {
+	Type string
+	Next int
+}
+
+// counterKey holds the *counterState of a conversion.
+var counterKey = parser.NewContextKey()
+
+// counterState is a seeded or recorded Counter. A seed stays pending until
+// the first top-level ordered list of the conversion has opened.
+type counterState struct {
+	counter Counter
+	pending bool
+}
+
+// SeedCounter makes the next conversion with pc continue numbering from c,
+// for documents rendered in fragments such as chat messages or live preview
+// chunks. If the first top-level ordered list of the fragment starts with a
+// bare '#' marker ("#." or "#)"), it takes c's type and starts at c.Next.
+// Any other first list keeps its own numbering. Pass pc to Convert with
+// parser.WithContext.
+func SeedCounter(pc parser.Context, c Counter) {
+	pc.Set(counterKey, &counterState{counter: c, pending: true})
+}
+
+// LastCounter returns the counter of the last top-level ordered list parsed
+// with pc, to seed the next fragment with SeedCounter. If the fragment had
+// no such list, the seed is returned unchanged. It reports false when
+// neither exists.
+func LastCounter(pc parser.Context) (Counter, bool) {
+	if s, ok := pc.Get(counterKey).(*counterState); ok {
+		return s.counter, true
+	}
+	return Counter{}, false
+}
+
+// pendingCounter returns the seeded counter when a list opening in parent
+// is the first top-level ordered list of the conversion.
+func pendingCounter(pc parser.Context, parent ast.Node) (Counter, bool) {
+	if _, nested := parent.(*ast.ListItem); nested {
+		return Counter{}, false
+	}
+	if s, ok := pc.Get(counterKey).(*counterState); ok && s.pending {
+		return s.counter, true
+	}
+	return Counter{}, false
+}
+
+// recordCounter records the counter at the end of list if it is a top-level
+// ordered list, clearing any pending seed.
+func recordCounter(pc parser.Context, list *ast.List) {
+	if _, nested := list.Parent().(*ast.ListItem); nested || !list.IsOrdered() {
+		return
+	}
+	last := itemValue(list.LastChild())
+	next := last + 1
+	if isReversed(list) {
+		next = last - 1
+	}
+	pc.Set(counterKey, &counterState{counter: Counter{Type: listType(list), Next: next}})
+}
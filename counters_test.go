@@ -0,0 +1,99 @@
+package fancylists
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/parser"
+)
+
+func TestCounterAcrossFragments(t *testing.T) {
+	md := goldmark.New(goldmark.WithExtensions(NewFancyLists()))
+	fragments := []struct {
+		source string
+		html   string
+		next   Counter
+	}{
+		{"c. Three\nd. Four\n", `<ol class="fancy fl-lcalpha" type="a" start="3">`, Counter{"a", 5}},
+		{"Some text\n", "<p>Some text</p>", Counter{"a", 5}},
+		{"#. Five\n   #. Nested\n", `<ol class="fancy fl-lcalpha" type="a" start="5">`, Counter{"a", 6}},
+		{"1. One\n", `<ol class="fancy fl-num" type="1" start="1">`, Counter{"1", 2}},
+	}
+	var counter Counter
+	for i, f := range fragments {
+		pc := parser.NewContext()
+		if i > 0 {
+			SeedCounter(pc, counter)
+		}
+		var out bytes.Buffer
+		if err := md.Convert([]byte(f.source), &out, parser.WithContext(pc)); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.HasPrefix(out.Bytes(), []byte(f.html)) {
+			t.Errorf("fragment %d rendered %q, want prefix %q", i, out.String(), f.html)
+		}
+		var ok bool
+		counter, ok = LastCounter(pc)
+		if !ok || counter != f.next {
+			t.Errorf("fragment %d counter = %+v, %v; want %+v", i, counter, ok, f.next)
+		}
+	}
+}
+
+func TestCounterSeedOnlyFirstList(t *testing.T) {
+	md := goldmark.New(goldmark.WithExtensions(NewFancyLists()))
+	pc := parser.NewContext()
+	SeedCounter(pc, Counter{"i", 4})
+	var out bytes.Buffer
+	if err := md.Convert([]byte("#. Four\n\nText\n\n#. One\n"), &out, parser.WithContext(pc)); err != nil {
+		t.Fatal(err)
+	}
+	want := `<ol class="fancy fl-lcroman" type="i" start="4">
+<li>Four</li>
+</ol>
+<p>Text</p>
+<ol class="fancy fl-num" type="1" start="1">
+<li>One</li>
+</ol>
+`
+	if out.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out.String(), want)
+	}
+	if _, ok := LastCounter(parser.NewContext()); ok {
+		t.Error("LastCounter reported a counter for an unused context")
+	}
+}
+
+func TestLastCounter(t *testing.T) {
+	tests := []struct {
+		source  string
+		options []Option
+		want    Counter
+	}{
+		{"a. One\n\nText\n\nIII. Three\nIV. Four\n\nMore text\n", nil, Counter{"I", 5}},
+		{"1. One\n   a. Nested\n   b. Nested\n", nil, Counter{"1", 2}},
+		{"3. Three\n#7. Seven\n", nil, Counter{"1", 8}},
+		{"c. Three\nb. Two\n", []Option{WithReversedLists()}, Counter{"a", 1}},
+		{"> ii. Two\n", nil, Counter{"i", 3}},
+	}
+	for _, tt := range tests {
+		md := goldmark.New(goldmark.WithExtensions(NewFancyLists(tt.options...)))
+		pc := parser.NewContext()
+		var out bytes.Buffer
+		if err := md.Convert([]byte(tt.source), &out, parser.WithContext(pc)); err != nil {
+			t.Fatal(err)
+		}
+		if got, ok := LastCounter(pc); !ok || got != tt.want {
+			t.Errorf("LastCounter after %q = %+v, %v; want %+v", tt.source, got, ok, tt.want)
+		}
+	}
+	pc := parser.NewContext()
+	var out bytes.Buffer
+	if err := goldmark.New(goldmark.WithExtensions(NewFancyLists())).Convert([]byte("- One\n"), &out, parser.WithContext(pc)); err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := LastCounter(pc); ok {
+		t.Errorf("LastCounter after a bullet list = %+v; want none", got)
+	}
+}
@@ -0,0 +1,51 @@
+package fancylists
+
+import (
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/util"
+)
+
+// parseDoubleDelimiterListItem is the counterpart of parseListItem for
+// ordered markers with the combined delimiter ".)" that word processors
+// export, as in "1.)" or "b.)". The marker is validated by parseListItem as
+// if it ended with the period.
+func parseDoubleDelimiterListItem(line []byte) ([6]int, listItemType) {
+	i := 0
+	l := len(line)
+	for ; i < l && i <= 3 && line[i] == ' '; i++ {
+	}
+	for ; i < l && (util.IsNumeric(line[i]) || isASCIILetter(line[i]) || line[i] == '#'); i++ {
+	}
+	if i+1 >= l || line[i] != '.' || line[i+1] != ')' {
+		return [6]int{}, notList
+	}
+	m, typ := parseListItem(line[:i+1])
+	if typ == notList || typ == bulletList {
+		return m, notList
+	}
+	i += 2
+	m[3] = i
+	return finishListItem(line, i, m, typ)
+}
+
+// isDoubleDelimiter reports whether a matched marker ends with ".)".
+func isDoubleDelimiter(line []byte, match [6]int) bool {
+	return match[3]-match[2] > 2 && line[match[3]-2] == '.' && line[match[3]-1] == ')'
+}
+
+// Delimiter returns the delimiter of an ordered list parsed by this
+// extension as it was written: "." or ")" for most lists, ".)" for double
+// delimiters (see WithDoubleDelimiters) and the original characters of
+// full-width markers such as "（）". It returns "" for other nodes.
+func Delimiter(list ast.Node) string {
+	l, ok := list.(*ast.List)
+	if !ok || !l.IsOrdered() {
+		return ""
+	}
+	if v, ok := l.Attribute(attrNameDelimiter); ok {
+		if delim, ok := v.([]byte); ok {
+			return string(delim)
+		}
+	}
+	return string(l.Marker)
+}
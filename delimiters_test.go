@@ -0,0 +1,32 @@
+package fancylists
+
+import (
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+func TestDelimiter(t *testing.T) {
+	md := goldmark.New(goldmark.WithExtensions(NewFancyLists(WithDoubleDelimiters(), WithFullWidthMarkers())))
+	source := []byte("1.) One\n2.) Two\n\n- Bullet\n\na) Paren\n\n（1） Full\n")
+	doc := md.Parser().Parse(text.NewReader(source))
+
+	var delims []string
+	for n := doc.FirstChild(); n != nil; n = n.NextSibling() {
+		delims = append(delims, Delimiter(n))
+	}
+	want := []string{".)", "", ")", "（）"}
+	if len(delims) != len(want) {
+		t.Fatalf("delimiters = %q; want %q", delims, want)
+	}
+	for i := range want {
+		if delims[i] != want[i] {
+			t.Errorf("delimiter %d = %q; want %q", i, delims[i], want[i])
+		}
+	}
+	if first := doc.FirstChild().(*ast.List); first.ChildCount() != 2 {
+		t.Errorf("first list has %d items; want 2", first.ChildCount())
+	}
+}
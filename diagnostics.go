@@ -0,0 +1,46 @@
+package fancylists
+
+import (
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+// Diagnostic describes a questionable list marker the parser accepted.
+type Diagnostic struct {
+	// Line is the 1-based source line of the marker.
+	Line int
+	// Offset is the byte offset of the marker in the source.
+	Offset int
+	// Marker is the marker text without its delimiter.
+	Marker string
+	// Message explains what was unusual about the marker.
+	Message string
+}
+
+var diagnosticsKey = parser.NewContextKey()
+
+// Diagnostics returns the diagnostics recorded while parsing with pc, in
+// source order. Pass the same parser.Context to Convert (via
+// parser.WithContext) and then to Diagnostics to retrieve them.
+func Diagnostics(pc parser.Context) []Diagnostic {
+	if d, ok := pc.Get(diagnosticsKey).(*[]Diagnostic); ok {
+		return *d
+	}
+	return nil
+}
+
+// addDiagnostic records a diagnostic for the marker at the reader's current line.
+func addDiagnostic(pc parser.Context, reader text.Reader, match [6]int, line []byte, message string) {
+	d, ok := pc.Get(diagnosticsKey).(*[]Diagnostic)
+	if !ok {
+		d = &[]Diagnostic{}
+		pc.Set(diagnosticsKey, d)
+	}
+	lineNo, _ := reader.Position()
+	*d = append(*d, Diagnostic{
+		Line:    lineNo + 1,
+		Offset:  markerSegment(reader, match).Start,
+		Marker:  string(markerText(line, match)),
+		Message: message,
+	})
+}
@@ -0,0 +1,44 @@
+package fancylists
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/parser"
+)
+
+func TestMixedCaseDiagnostics(t *testing.T) {
+	md := goldmark.New(goldmark.WithExtensions(NewFancyLists(WithMixedCase(MixedCaseDiagnose))))
+	source := []byte("Intro\n\nIi. First\nIII. Second\nxIv. Third\n")
+	pc := parser.NewContext()
+	var out bytes.Buffer
+	if err := md.Convert(source, &out, parser.WithContext(pc)); err != nil {
+		t.Fatal(err)
+	}
+	got := Diagnostics(pc)
+	if len(got) != 2 {
+		t.Fatalf("got %d diagnostics, want 2: %+v", len(got), got)
+	}
+	want := []struct {
+		line   int
+		offset int
+		marker string
+	}{{3, 7, "Ii"}, {5, 29, "xIv"}}
+	for i, w := range want {
+		if got[i].Line != w.line || got[i].Offset != w.offset || got[i].Marker != w.marker {
+			t.Errorf("diagnostic %d = %+v, want line %d offset %d marker %q", i, got[i], w.line, w.offset, w.marker)
+		}
+	}
+}
+
+func TestNoDiagnosticsByDefault(t *testing.T) {
+	pc := parser.NewContext()
+	var out bytes.Buffer
+	if err := mdBasic.Convert([]byte("Ii. First\n"), &out, parser.WithContext(pc)); err != nil {
+		t.Fatal(err)
+	}
+	if got := Diagnostics(pc); len(got) != 0 {
+		t.Errorf("got diagnostics with the default policy: %+v", got)
+	}
+}
@@ -0,0 +1,152 @@
+package fancylists
+
+import (
+	"strconv"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/util"
+)
+
+// docBookNumerations maps list type attribute values to DocBook numeration
+// values.
+var docBookNumerations = map[string]string{
+	"1": "arabic",
+	"a": "loweralpha",
+	"A": "upperalpha",
+	"i": "lowerroman",
+	"I": "upperroman",
+}
+
+// DocBookRenderer renders lists as DocBook <orderedlist> and <itemizedlist>
+// elements, for documentation toolchains that consume DocBook. It renders
+// only lists, list items and the paragraphs and text blocks of items, as
+// <para>; register it ahead of the renderer that writes the rest of the
+// document. Inline content such as emphasis, code spans and links is left
+// to that renderer and stays HTML (<em>, <code>, <a>), so items using it
+// need a further conversion to be valid DocBook.
+//
+//	goldmark.New(
+//		goldmark.WithExtensions(fancylists.NewFancyLists()),
+//		goldmark.WithRendererOptions(renderer.WithNodeRenderers(
+//			util.Prioritized(fancylists.NewDocBookRenderer(), 100),
+//		)),
+//	)
+type DocBookRenderer struct {
+	options FancyListsOptions
+}
+
+// NewDocBookRenderer returns a DocBookRenderer. Of the options, Compact and
+// ItemValues affect its output.
+func NewDocBookRenderer(opts ...Option) *DocBookRenderer {
+	return &DocBookRenderer{options: *NewFancyLists(opts...)}
+}
+
+// RegisterFuncs implements renderer.NodeRenderer.
+func (r *DocBookRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindList, r.renderList)
+	reg.Register(ast.KindListItem, r.renderListItem)
+	reg.Register(ast.KindTextBlock, r.renderTextBlock)
+	reg.Register(ast.KindParagraph, r.renderParagraph)
+}
+
+// renderList writes an <orderedlist> with its numeration and starting
+// number, or an <itemizedlist>. Tight lists are marked compact.
+func (r *DocBookRenderer) renderList(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	n := node.(*ast.List)
+	tag := "itemizedlist"
+	if n.IsOrdered() {
+		tag = "orderedlist"
+	}
+	if entering {
+		_ = w.WriteByte('<')
+		_, _ = w.WriteString(tag)
+		if n.IsOrdered() {
+			_, _ = w.WriteString(` numeration="`)
+			_, _ = w.WriteString(docBookNumerations[listType(n)])
+			_ = w.WriteByte('"')
+			if n.Start != 1 {
+				_, _ = w.WriteString(` startingnumber="`)
+				_, _ = w.WriteString(strconv.Itoa(n.Start))
+				_ = w.WriteByte('"')
+			}
+		}
+		if n.IsTight {
+			_, _ = w.WriteString(` spacing="compact"`)
+		}
+		_ = w.WriteByte('>')
+	} else {
+		_, _ = w.WriteString("</")
+		_, _ = w.WriteString(tag)
+		_ = w.WriteByte('>')
+	}
+	if !r.options.Compact {
+		_ = w.WriteByte('\n')
+	}
+	return ast.WalkContinue, nil
+}
+
+// renderListItem writes a <listitem>. Items whose value was set explicitly
+// with '#5.', or every ordered item when ItemValues is set, carry it as the
+// override attribute.
+func (r *DocBookRenderer) renderListItem(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		_, _ = w.WriteString("<listitem")
+		if _, explicit := n.Attribute(attrNameExplicit); r.options.ItemValues || explicit {
+			if value := itemValue(n); value > 0 {
+				_, _ = w.WriteString(` override="`)
+				_, _ = w.WriteString(strconv.Itoa(value))
+				_ = w.WriteByte('"')
+			}
+		}
+		_ = w.WriteByte('>')
+		if !r.options.Compact {
+			_ = w.WriteByte('\n')
+		}
+	} else {
+		_, _ = w.WriteString("</listitem>")
+		if !r.options.Compact {
+			_ = w.WriteByte('\n')
+		}
+	}
+	return ast.WalkContinue, nil
+}
+
+// renderTextBlock wraps the text of tight list items in <para>, since a
+// DocBook <listitem> holds only block elements.
+func (r *DocBookRenderer) renderTextBlock(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		_, _ = w.WriteString("<para>")
+	} else {
+		_, _ = w.WriteString("</para>")
+		if !r.options.Compact {
+			_ = w.WriteByte('\n')
+		}
+	}
+	return ast.WalkContinue, nil
+}
+
+// renderParagraph wraps the paragraphs of loose list items in <para>, and
+// writes other paragraphs as Goldmark's core renderer does.
+func (r *DocBookRenderer) renderParagraph(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if n.Parent() == nil || n.Parent().Kind() != ast.KindListItem {
+		writeHTMLParagraph(w, n, entering)
+		return ast.WalkContinue, nil
+	}
+	return r.renderTextBlock(w, source, n, entering)
+}
+
+// writeHTMLParagraph writes the tags of paragraph n as Goldmark's core
+// renderer does.
+func writeHTMLParagraph(w util.BufWriter, n ast.Node, entering bool) {
+	if !entering {
+		_, _ = w.WriteString("</p>\n")
+	} else if n.Attributes() != nil {
+		_, _ = w.WriteString("<p")
+		html.RenderAttributes(w, n, html.ParagraphAttributeFilter)
+		_ = w.WriteByte('>')
+	} else {
+		_, _ = w.WriteString("<p>")
+	}
+}
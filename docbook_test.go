@@ -0,0 +1,72 @@
+package fancylists
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+func TestDocBookRenderer(t *testing.T) {
+	md := goldmark.New(
+		goldmark.WithExtensions(NewFancyLists()),
+		goldmark.WithRendererOptions(renderer.WithNodeRenderers(
+			util.Prioritized(NewDocBookRenderer(), 100),
+		)),
+	)
+	source := "iii. Three\n#. Four\n   - Bullet\n#7. Seven\n"
+	want := `<orderedlist numeration="lowerroman" startingnumber="3" spacing="compact">
+<listitem>
+<para>Three</para>
+</listitem>
+<listitem>
+<para>Four</para>
+<itemizedlist spacing="compact">
+<listitem>
+<para>Bullet</para>
+</listitem>
+</itemizedlist>
+</listitem>
+<listitem override="7">
+<para>Seven</para>
+</listitem>
+</orderedlist>
+`
+	var out bytes.Buffer
+	if err := md.Convert([]byte(source), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestDocBookRendererLooseList(t *testing.T) {
+	md := goldmark.New(
+		goldmark.WithExtensions(NewFancyLists()),
+		goldmark.WithRendererOptions(renderer.WithNodeRenderers(
+			util.Prioritized(NewDocBookRenderer(), 100),
+		)),
+	)
+	source := "Intro\n\nA. One\n\n   More\n\nB. Two\n"
+	want := `<p>Intro</p>
+<orderedlist numeration="upperalpha">
+<listitem>
+<para>One</para>
+<para>More</para>
+</listitem>
+<listitem>
+<para>Two</para>
+</listitem>
+</orderedlist>
+`
+	var out bytes.Buffer
+	if err := md.Convert([]byte(source), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
@@ -0,0 +1,172 @@
+package fancylists
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// CoreDifference is a place where the tree parsed with this extension
+// differs from the tree Goldmark's core parser builds for the same source.
+type CoreDifference struct {
+	// Line is the 1-based source line of the differing node, or 0 when the
+	// node has no position.
+	Line int
+	// Path locates the node from the document, such as
+	// "Document/List[0]/ListItem[1]"; indexes count siblings.
+	Path string
+	// Core describes the node in the core tree, or is empty if it has no
+	// counterpart there.
+	Core string
+	// Fancy describes the node in the extension's tree, or is empty if it
+	// has no counterpart there.
+	Fancy string
+}
+
+// String formats the difference as "line 3: Document/List[0]: core List
+// ordered start=1 tight, fancy List ordered start=1 loose".
+func (d CoreDifference) String() string {
+	return fmt.Sprintf("line %d: %s: core %s, fancy %s", d.Line, d.Path, orNone(d.Core), orNone(d.Fancy))
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
+// CompareWithCore parses source with Goldmark's core parser and with this
+// extension configured by opts, and returns where the two trees differ. For
+// input that uses only CommonMark list markers the extension is meant to
+// build the same tree, so differences show behavioral drift; integrators
+// can run it over their own documents before upgrading or changing options.
+// When source contains markers only the extension reads, such as "a." or
+// "#.", differences are expected: CompareWithCore then reports fancy as
+// true and returns no differences.
+func CompareWithCore(source []byte, opts ...Option) (diffs []CoreDifference, fancy bool) {
+	core := goldmark.New().Parser().Parse(text.NewReader(source))
+	md := goldmark.New(goldmark.WithExtensions(NewFancyLists(opts...)))
+	doc := md.Parser().Parse(text.NewReader(source))
+	if HasFancyMarkers(doc, source) {
+		return nil, true
+	}
+	return CompareTrees(core, doc, source), false
+}
+
+// HasFancyMarkers reports whether a document parsed with this extension
+// contains a list whose markers CommonMark does not define: letters, roman
+// numerals, '#', prefixes, suffixes, full-width or Arabic-Indic digits, or
+// configured bullets.
+func HasFancyMarkers(doc ast.Node, source []byte) bool {
+	fancy := false
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		if _, ok := n.(*ast.List); ok && (!isPlainList(n) || listBullet(n) != nil) {
+			fancy = true
+			return ast.WalkStop, nil
+		}
+		if segment, ok := MarkerSegment(n); ok && bytes.HasPrefix(segment.Value(source), []byte("#")) {
+			fancy = true
+			return ast.WalkStop, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return fancy
+}
+
+// CompareTrees compares two parses of source, core without this extension
+// and fancy with it, for integrators whose Goldmark configuration includes
+// other extensions. Nodes are compared by kind and by the properties that
+// shape the output: list kind, start, marker and tightness, heading level
+// and text. Attributes are ignored. Where two nodes differ their children
+// are not compared.
+func CompareTrees(core, fancy ast.Node, source []byte) []CoreDifference {
+	var diffs []CoreDifference
+	compareNodes(core, fancy, source, core.Kind().String(), &diffs)
+	return diffs
+}
+
+// compareNodes records the differences between a and b, found at path, and
+// between their children.
+func compareNodes(a, b ast.Node, source []byte, path string, diffs *[]CoreDifference) {
+	da, db := describeNode(a, source), describeNode(b, source)
+	if da != db {
+		*diffs = append(*diffs, CoreDifference{Line: nodeLine(b, source), Path: path, Core: da, Fancy: db})
+		return
+	}
+	counts := map[ast.NodeKind]int{}
+	ca, cb := a.FirstChild(), b.FirstChild()
+	for ca != nil || cb != nil {
+		n := ca
+		if n == nil {
+			n = cb
+		}
+		kind := n.Kind()
+		childPath := path + "/" + kind.String() + "[" + strconv.Itoa(counts[kind]) + "]"
+		counts[kind]++
+		switch {
+		case ca == nil:
+			*diffs = append(*diffs, CoreDifference{Line: nodeLine(cb, source), Path: childPath, Fancy: describeNode(cb, source)})
+		case cb == nil:
+			*diffs = append(*diffs, CoreDifference{Line: nodeLine(ca, source), Path: childPath, Core: describeNode(ca, source)})
+		default:
+			compareNodes(ca, cb, source, childPath, diffs)
+		}
+		if ca != nil {
+			ca = ca.NextSibling()
+		}
+		if cb != nil {
+			cb = cb.NextSibling()
+		}
+	}
+}
+
+// describeNode returns the kind of n and the properties CompareTrees
+// compares.
+func describeNode(n ast.Node, source []byte) string {
+	desc := n.Kind().String()
+	switch n := n.(type) {
+	case *ast.List:
+		if n.IsOrdered() {
+			desc += " ordered start=" + strconv.Itoa(n.Start) + " delimiter=" + string(n.Marker)
+		} else {
+			desc += " bullet=" + string(n.Marker)
+		}
+		if n.IsTight {
+			desc += " tight"
+		} else {
+			desc += " loose"
+		}
+	case *ast.Heading:
+		desc += " level=" + strconv.Itoa(n.Level)
+	case *ast.Text:
+		desc += " " + strconv.Quote(string(n.Segment.Value(source)))
+	case *ast.String:
+		desc += " " + strconv.Quote(string(n.Value))
+	}
+	return desc
+}
+
+// nodeLine returns the 1-based source line where n, or its first
+// positioned descendant, starts, or 0 if none has a position.
+func nodeLine(n ast.Node, source []byte) int {
+	for ; n != nil; n = n.FirstChild() {
+		offset := -1
+		if t, ok := n.(*ast.Text); ok {
+			offset = t.Segment.Start
+		} else if n.Type() == ast.TypeBlock && n.Lines().Len() > 0 {
+			offset = n.Lines().At(0).Start
+		}
+		if offset >= 0 && offset <= len(source) {
+			return bytes.Count(source[:offset], []byte("\n")) + 1
+		}
+	}
+	return 0
+}
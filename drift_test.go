@@ -0,0 +1,55 @@
+package fancylists
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestCompareWithCoreCommonMark(t *testing.T) {
+	data, err := os.ReadFile("testdata/commonmark_lists.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cases []commonMarkCase
+	if err := json.Unmarshal(data, &cases); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range cases {
+		diffs, fancy := CompareWithCore([]byte(c.Markdown))
+		if fancy {
+			t.Errorf("example %d: reported fancy markers", c.Example)
+		}
+		for _, d := range diffs {
+			t.Errorf("example %d: %s", c.Example, d)
+		}
+	}
+}
+
+func TestCompareWithCoreFancy(t *testing.T) {
+	for _, source := range []string{"a. One\n", "1. One\n#. Two\n", "Step 1. One\n"} {
+		if diffs, fancy := CompareWithCore([]byte(source), WithStepMarkers()); !fancy || diffs != nil {
+			t.Errorf("CompareWithCore(%q) = %v, %v; want no differences and fancy", source, diffs, fancy)
+		}
+	}
+}
+
+func TestCompareWithCoreDrift(t *testing.T) {
+	source := []byte("Intro\n\n1. One\n\n\n2. Two\n")
+	diffs, fancy := CompareWithCore(source, WithDoubleBlankLineEnd())
+	if fancy {
+		t.Fatal("reported fancy markers")
+	}
+	want := []string{
+		"line 3: Document/List[0]: core List ordered start=1 delimiter=. loose, fancy List ordered start=1 delimiter=. tight",
+		"line 6: Document/List[1]: core (none), fancy List ordered start=2 delimiter=. tight",
+	}
+	if len(diffs) != len(want) {
+		t.Fatalf("got %d differences, want %d: %v", len(diffs), len(want), diffs)
+	}
+	for i, d := range diffs {
+		if d.String() != want[i] {
+			t.Errorf("difference %d = %q, want %q", i, d, want[i])
+		}
+	}
+}
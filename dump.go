@@ -0,0 +1,118 @@
+package fancylists
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// Dump writes the tree of a parsed document for debugging, one node per
+// line indented by depth. Unlike Goldmark's ast.Node.Dump it shows what
+// this extension recorded on lists and items in a legible form:
+//
+//	List ordered type=a start=2 delimiter=")" tight marker="b)" type="a"
+//	  ListItem value=2 marker="b)"
+//	    TextBlock
+//	      Text "First"
+//
+// Other attributes, such as classes added with goldmark-attributes, follow
+// as name=value pairs.
+func Dump(w io.Writer, doc ast.Node, source []byte) error {
+	bw := bufio.NewWriter(w)
+	depth := 0
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			depth--
+			return ast.WalkContinue, nil
+		}
+		_, _ = bw.WriteString(strings.Repeat("  ", depth))
+		_, _ = bw.WriteString(n.Kind().String())
+		for _, field := range dumpFields(n, source) {
+			_ = bw.WriteByte(' ')
+			_, _ = bw.WriteString(field)
+		}
+		_ = bw.WriteByte('\n')
+		depth++
+		return ast.WalkContinue, nil
+	})
+	return bw.Flush()
+}
+
+// dumpFields returns the fields Dump writes after the kind of n.
+func dumpFields(n ast.Node, source []byte) []string {
+	var fields []string
+	switch n := n.(type) {
+	case *ast.List:
+		if n.IsOrdered() {
+			fields = append(fields, "ordered", "type="+listType(n), "start="+strconv.Itoa(n.Start),
+				"delimiter="+strconv.Quote(Delimiter(n)))
+		} else {
+			bullet := string(n.Marker)
+			if b := listBullet(n); b != nil {
+				bullet = string(b)
+			}
+			fields = append(fields, "bullet="+strconv.Quote(bullet))
+		}
+		if prefix := listPrefix(n); prefix != nil {
+			fields = append(fields, "prefix="+strconv.Quote(string(prefix)))
+		}
+		if suffix := listSuffix(n); suffix != nil {
+			fields = append(fields, "suffix="+strconv.Quote(string(suffix)))
+		}
+		if width := listPadding(n); width > 0 {
+			fields = append(fields, "padding="+strconv.Itoa(width))
+		}
+		if v, ok := n.Attribute(attrNameDigits); ok {
+			fields = append(fields, "digits="+dumpValue(v))
+		}
+		if isReversed(n) {
+			fields = append(fields, "reversed")
+		}
+		if n.IsTight {
+			fields = append(fields, "tight")
+		} else {
+			fields = append(fields, "loose")
+		}
+	case *ast.ListItem:
+		if _, ok := n.Attribute(attrNameValue); ok {
+			fields = append(fields, "value="+strconv.Itoa(itemValue(n)))
+		}
+		if _, ok := n.Attribute(attrNameExplicit); ok {
+			fields = append(fields, "explicit")
+		}
+	case *ast.Heading:
+		fields = append(fields, "level="+strconv.Itoa(n.Level))
+	case *ast.Text:
+		fields = append(fields, strconv.Quote(string(n.Segment.Value(source))))
+	case *ast.String:
+		fields = append(fields, strconv.Quote(string(n.Value)))
+	}
+	if segment, ok := MarkerSegment(n); ok {
+		fields = append(fields, "marker="+strconv.Quote(string(segment.Value(source))))
+	}
+	for _, attr := range n.Attributes() {
+		if !isInternalAttribute(attr.Name) && !bytes.Equal(attr.Name, attrNameValue) {
+			fields = append(fields, string(attr.Name)+"="+dumpValue(attr.Value))
+		}
+	}
+	return fields
+}
+
+// dumpValue formats an attribute value for Dump.
+func dumpValue(v interface{}) string {
+	switch v := v.(type) {
+	case []byte:
+		return strconv.Quote(string(v))
+	case string:
+		return strconv.Quote(v)
+	case text.Segment:
+		return fmt.Sprintf("[%d:%d]", v.Start, v.Stop)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,38 @@
+package fancylists
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/text"
+)
+
+func TestDump(t *testing.T) {
+	md := goldmark.New(goldmark.WithExtensions(NewFancyLists(WithBlockAttributes())))
+	source := []byte("b) First\nc) Second\n   - nested\n\n#5) Fifth\n{.steps}\n")
+	doc := md.Parser().Parse(text.NewReader(source))
+	var out bytes.Buffer
+	if err := Dump(&out, doc, source); err != nil {
+		t.Fatal(err)
+	}
+	want := `Document
+  List ordered type=a start=2 delimiter=")" loose marker="b)" class="steps" type="a"
+    ListItem value=2 marker="b)"
+      Paragraph
+        Text "First"
+    ListItem value=3 marker="c)"
+      Paragraph
+        Text "Second"
+      List bullet="-" tight marker="-"
+        ListItem marker="-"
+          TextBlock
+            Text "nested"
+    ListItem value=5 explicit marker="#5)"
+      Paragraph
+        Text "Fifth"
+`
+	if got := out.String(); got != want {
+		t.Errorf("Dump:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,46 @@
+package fancylists
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+)
+
+func TestEmptyItems(t *testing.T) {
+	source := []byte("a. One\nb.\nc. Three\n")
+	cases := []struct {
+		mode  EmptyItemMode
+		xhtml bool
+		want  string
+	}{
+		{EmptyItemsInline, false, "<li></li>\n"},
+		{EmptyItemsNewline, false, "<li>\n</li>\n"},
+		{EmptyItemsSelfClosing, true, "<li />\n"},
+		{EmptyItemsSelfClosing, false, "<li></li>\n"},
+	}
+	for _, c := range cases {
+		var opts []renderer.Option
+		if c.xhtml {
+			opts = append(opts, html.WithXHTML())
+		}
+		md := goldmark.New(
+			goldmark.WithExtensions(NewFancyLists(WithEmptyItems(c.mode))),
+			goldmark.WithRendererOptions(opts...),
+		)
+		var out bytes.Buffer
+		if err := md.Convert(source, &out); err != nil {
+			t.Fatal(err)
+		}
+		want := `<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>One</li>
+` + c.want + `<li>Three</li>
+</ol>
+`
+		if out.String() != want {
+			t.Errorf("mode %d, XHTML %v:\ngot:\n%s\nwant:\n%s", c.mode, c.xhtml, out.String(), want)
+		}
+	}
+}
@@ -3,13 +3,15 @@
 package fancylists
 
 import (
+	"bytes"
+	"sort"
 	"strconv"
-	"strings"
-	"unicode"
 
 	"github.com/brandenc40/romannumeral"
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	east "github.com/yuin/goldmark/extension/ast"
 	"github.com/yuin/goldmark/parser"
 	"github.com/yuin/goldmark/renderer"
 	"github.com/yuin/goldmark/renderer/html"
@@ -28,29 +30,370 @@ const (
 	orderedListFancy
 )
 
-// Internal parser context keys for state management.
+// listItemFlagValue is the value of internal attributes that only mark a
+// node.
+var listItemFlagValue interface{} = true
+
+// Interned attribute names and type values. They are shared by every node the
+// parsers create, so they must never be modified.
 var (
-	skipListParserKey           = parser.NewContextKey()
-	emptyListItemWithBlankLines = parser.NewContextKey()
-	listItemFlagValue           interface{} = true
+	attrNameType      = []byte("type")
+	attrNameDir       = []byte("dir")
+	attrNameListType  = []byte("fl-type")
+	attrNameValue     = []byte("value")
+	attrNameExplicit  = []byte("fl-value")
+	attrNamePadding   = []byte("fl-padding")
+	attrNameDelimiter = []byte("fl-delimiter")
+	attrNameDigits    = []byte("fl-digits")
+	attrNameColumn    = []byte("fl-column")
+	typeLowerAlpha    = []byte("a")
+	typeUpperAlpha    = []byte("A")
+	typeLowerRoman    = []byte("i")
+	typeUpperRoman    = []byte("I")
 )
 
-// FancyLists extends Goldmark to support fancy list markers.
-type FancyListsOptions struct{}
+// FancyListsOptions extends Goldmark to support fancy list markers.
+// The zero value uses the default behavior; see NewFancyLists and the
+// With* functions for the available options.
+//
+// A single FancyListsOptions value may be registered with any number of
+// Goldmark instances, and those instances may convert documents concurrently.
+// Extend copies the options into the parsers and renderers it registers, and
+// all per-conversion state lives in the parser.Context, so changing the value
+// after calling Extend does not affect instances already created with it.
+type FancyListsOptions struct {
+	// ClassMap replaces the classes written for the list types it maps (see
+	// WithClassMap). Extend copies it, so changing it later has no effect.
+	ClassMap ClassMap
+
+	// Compact renders each list on a single line (see WithCompact).
+	Compact bool
+
+	// Padding selects how zero-padded numeric markers are rendered (see WithPadding).
+	Padding PaddingMode
+
+	// ItemValues writes the computed number of each ordered item as a value
+	// attribute on its <li> (see WithItemValues).
+	ItemValues bool
+
+	// AmbiguousMarkers selects how standalone i/v/x markers are read (see WithAmbiguousMarkers).
+	AmbiguousMarkers AmbiguityPolicy
+
+	// MixedCase selects how markers mixing upper and lower case are handled (see WithMixedCase).
+	MixedCase MixedCasePolicy
+
+	// FullWidthMarkers accepts full-width markers such as "（a）" and "１）" (see WithFullWidthMarkers).
+	FullWidthMarkers bool
+
+	// ArabicIndicDigits accepts numeric markers written with Arabic-Indic
+	// digits such as "١." and "۱)" (see WithArabicIndicDigits).
+	ArabicIndicDigits bool
+
+	// Alphabet replaces the Latin alphabet used to recognize and number
+	// alphabetic markers (see WithAlphabet).
+	Alphabet string
+
+	// AttributePolicy selects how user-supplied class and type attributes
+	// combine with the computed ones (see WithAttributePolicy).
+	AttributePolicy AttributePolicy
+
+	// AllowedAttributes and DeniedAttributes filter the attributes passed
+	// through from the user unless unsafe rendering is enabled (see
+	// WithAttributeFilter).
+	AllowedAttributes []string
+	DeniedAttributes  []string
+
+	// BareHashMarkers accepts "# item" as the next item of an open ordered
+	// list (see WithBareHashMarkers).
+	BareHashMarkers bool
+
+	// Checklists selects how ordered lists of task items are rendered (see
+	// WithChecklists).
+	Checklists ChecklistMode
+
+	// Offsets selects how indentation inside block quotes is measured (see
+	// WithOffsets).
+	Offsets OffsetMode
+
+	// Types holds the enabled marker families; zero enables all of them (see
+	// WithTypes).
+	Types MarkerType
+
+	// Reversed renders ordered lists whose markers count down ("3. 2. 1.",
+	// "c. b. a.") as reversed lists (see WithReversedLists).
+	Reversed bool
+
+	// StepMarkers accepts "Step 1." markers (see WithStepMarkers).
+	StepMarkers bool
+	// LegalMarkers accepts "§ 1." and "Article I." markers (see
+	// WithLegalMarkers).
+	LegalMarkers bool
+	// MarkerPrefixes lists further text accepted before a counter, as in
+	// "Q1." or "Task 3)" (see WithMarkerPrefixes).
+	MarkerPrefixes []string
+
+	// OrdinalMarkers accepts numeric markers with an ordinal suffix such as
+	// "1º" and "2ª." (see WithOrdinalMarkers).
+	OrdinalMarkers bool
+
+	// DoubleDelimiters accepts ordered markers ending in ".)" such as "1.)"
+	// (see WithDoubleDelimiters).
+	DoubleDelimiters bool
+
+	// HashDepthTypes gives lists opened by a bare '#' marker their type by
+	// nesting depth, cycling through the entries (see WithHashDepthTypes).
+	HashDepthTypes []MarkerType
+
+	// OutlineTypes rewrites nested bullet lists as ordered lists typed by
+	// depth from these types (see WithOutlineNumbering).
+	OutlineTypes []MarkerType
+
+	// HeadingNumbers numbers headings like nested lists, with types by depth
+	// from HeadingTypes, or 1, 1, a, i when it is empty (see
+	// WithHeadingNumbers).
+	HeadingNumbers HeadingNumberMode
+	HeadingTypes   []MarkerType
+
+	// Quizzes renders uppercase alphabetic lists with a quiz attribute as
+	// radio button groups (see WithQuizzes).
+	Quizzes bool
+
+	// CommentContinuation merges lists of the same type separated only by
+	// HTML comments (see WithCommentContinuation).
+	CommentContinuation bool
+
+	// DoubleBlankLineEnd ends lists at two consecutive blank lines (see
+	// WithDoubleBlankLineEnd).
+	DoubleBlankLineEnd bool
+
+	// LazyContinuation selects whether unindented lines continue the
+	// paragraph of a list item (see WithLazyContinuation).
+	LazyContinuation LazyContinuationMode
+
+	// EmptyItems selects how items without content are written (see
+	// WithEmptyItems).
+	EmptyItems EmptyItemMode
+
+	// HangingIndent is the indent, counted from the marker, that
+	// continuation content must reach; zero aligns it to the content after
+	// the marker (see WithHangingIndent).
+	HangingIndent int
+
+	// Interrupt selects which ordered lists may interrupt a paragraph (see
+	// WithParagraphInterrupt).
+	Interrupt InterruptPolicy
+
+	// LargeRoman selects what happens to roman numeral markers above 3999
+	// (see WithLargeRoman).
+	LargeRoman LargeRomanPolicy
+
+	// UnicodeRoman accepts markers written with the roman numeral characters
+	// such as "Ⅲ." and "ⅻ)" (see WithUnicodeRoman).
+	UnicodeRoman bool
+
+	// ParenthesizedMarkers accepts the single-character markers "⑴" and "⒜"
+	// (see WithParenthesizedMarkers).
+	ParenthesizedMarkers bool
+
+	// Bullets holds bullet characters accepted besides '-', '+' and '*',
+	// such as "•◦▪" (see WithBullets).
+	Bullets string
+
+	// DashBullets accepts the en and em dashes as bullets (see
+	// WithDashBullets).
+	DashBullets bool
+
+	// AlphaNumbering selects how multi-letter alphabetic markers are valued
+	// (see WithAlphaNumbering).
+	AlphaNumbering AlphaNumbering
+
+	// Abbreviations holds tokens such as "vs." and "etc." that are never
+	// read as letter markers (see WithAbbreviations). Nil uses the default
+	// stop-list; an empty list turns it off.
+	Abbreviations []string
+
+	// Regions names the fenced containers outside of which only CommonMark
+	// markers are read (see WithFancyRegions).
+	Regions string
+
+	// InlineStyles writes the list-style-type of lists in a style attribute
+	// (see WithInlineStyles).
+	InlineStyles InlineStyleMode
+
+	// Trace receives the decisions of the list parsers, for debugging (see
+	// WithTrace).
+	Trace func(TraceEvent) `json:"-"`
+
+	// ListItemRenderer replaces the <li> tags the extension writes (see
+	// WithListItemRenderer).
+	ListItemRenderer ListItemRenderer `json:"-"`
+
+	// AttributeProviders read user attributes of lists from other attribute
+	// syntaxes (see WithAttributeProviders).
+	AttributeProviders []AttributeProvider `json:"-"`
+
+	// BoundaryComments writes HTML comments around fancy lists (see
+	// WithBoundaryComments).
+	BoundaryComments bool
+
+	// Renumber numbers every ordered list from 1, ignoring the numbers of
+	// its markers (see WithRenumbering).
+	Renumber bool
+
+	// OmitNumericType leaves out type="1" on numeric lists (see
+	// WithOmitNumericType).
+	OmitNumericType bool
+
+	// ItemLabels writes the label of each ordered item in a data-label
+	// attribute (see WithItemLabels).
+	ItemLabels bool
+
+	// Microdata annotates lists with schema.org ItemList microdata (see
+	// WithMicrodata).
+	Microdata bool
+
+	// Minimal renders lists with nothing but the attributes common HTML
+	// sanitizers keep (see WithMinimalOutput).
+	Minimal bool
+	// Semantic renders lists with nothing but type, start and reversed (see
+	// WithSemanticOutput).
+	Semantic bool
+
+	// Indent is written once per level of list nesting before list and
+	// item tags (see WithIndentedOutput).
+	Indent string
+
+	// MarkerSpans writes each ordered item's marker in a span instead of
+	// numbering the list (see WithMarkerSpans).
+	MarkerSpans MarkerSpanMode
+
+	// DelimiterClasses adds ClassPeriod or ClassParen to ordered lists (see
+	// WithDelimiterClasses).
+	DelimiterClasses bool
+
+	// BulletPassthrough leaves bullet lists to Goldmark's core list parser
+	// and renderer (see WithBulletPassthrough).
+	BulletPassthrough bool
+
+	// BulletClasses adds ClassDisc, ClassCircle or ClassSquare to bullet
+	// lists by nesting depth (see WithBulletClasses).
+	BulletClasses bool
+
+	// RelaxedIndent parses markers indented four to seven spaces as list
+	// items instead of indented code (see WithRelaxedIndent).
+	RelaxedIndent bool
+
+	// CommonMark renders lists that use only CommonMark markers exactly as
+	// Goldmark's core renderer does (see WithCommonMarkOutput).
+	CommonMark bool
+
+	// BlockAttributes applies a "{.class key=val}" line directly below a list
+	// to that list, without goldmark-attributes (see WithBlockAttributes).
+	BlockAttributes bool
+
+	// MaxStart caps computed start values; zero means no cap (see WithMaxStart).
+	MaxStart int
+	// MaxStartPolicy selects what happens to a start value above MaxStart.
+	MaxStartPolicy StartLimitPolicy
+
+	// ParserOrder selects where the list parsers are registered among the
+	// block parsers of other extensions (see WithParserOrder).
+	ParserOrder ParserOrder
+}
 
 // Helper variable for default options
 var FancyLists = &FancyListsOptions{}
 
 // Extend implements goldmark.Extender interface to register parsers and renderers.
+//
+// Goldmark's core list parsers stay registered behind the extension's,
+// which are offered every line first. They open only the lists the options
+// leave to them (see BulletPassthrough and Types), and every list while
+// Disable(pc) switches the extension off for part of a conversion. The two
+// never add items to each other's lists.
 func (e *FancyListsOptions) Extend(m goldmark.Markdown) {
+	opts := *e
+	if opts.ClassMap != nil {
+		// The renderers keep a copy of their own, completed with the
+		// default classes, so callers cannot change it mid-conversion
+		opts.ClassMap = defaultClassMap.with(opts.ClassMap)
+	}
+	listPriority, itemPriority := 100, 101
+	if opts.ParserOrder == ParsersLast {
+		listPriority, itemPriority = 298, 299
+	}
 	m.Parser().AddOptions(parser.WithBlockParsers(
-		util.Prioritized(&fancyListParser{}, 100),     // Higher priority than default list parser (300)
-		util.Prioritized(&fancyListItemParser{}, 101), // Higher priority than default list item parser (400)
+		util.Prioritized(&fancyListParser{opts}, listPriority),     // Higher priority than default list parser (300)
+		util.Prioritized(&fancyListItemParser{opts}, itemPriority), // Higher priority than default list item parser (400)
 	))
 	m.Renderer().AddOptions(renderer.WithNodeRenderers(
-		util.Prioritized(&fancyListHTMLRenderer{html.NewConfig()}, 500),
-		util.Prioritized(&fancyListItemHTMLRenderer{html.NewConfig()}, 500),
+		util.Prioritized(&fancyListHTMLRenderer{html.NewConfig(), opts}, 500),
+		util.Prioritized(&fancyListItemHTMLRenderer{html.NewConfig(), opts}, 500),
 	))
+	m.Parser().AddOptions(parser.WithASTTransformers(
+		util.Prioritized(&listTypeTransformer{}, 1000), // After goldmark-attributes (100)
+	))
+	if opts.OutlineTypes != nil {
+		m.Parser().AddOptions(parser.WithASTTransformers(
+			util.Prioritized(NewOutlineTransformer(opts.OutlineTypes...), 999), // Ahead of listTypeTransformer
+		))
+	}
+	if opts.HeadingNumbers != HeadingNumbersOff {
+		m.Parser().AddOptions(parser.WithASTTransformers(
+			util.Prioritized(&headingNumberTransformer{opts}, 1000),
+		))
+	}
+	if opts.CommentContinuation {
+		m.Parser().AddOptions(parser.WithASTTransformers(
+			util.Prioritized(&commentContinuationTransformer{}, 999),
+		))
+	}
+	if opts.LazyContinuation == LazyIndented {
+		m.Parser().AddOptions(parser.WithBlockParsers(
+			util.Prioritized(&lazyBreakParser{parser.NewParagraphParser()}, 999), // Ahead of the paragraph parser (1000)
+		))
+	}
+	if opts.Checklists != ChecklistOff {
+		extension.TaskList.Extend(m)
+	}
+	if opts.Renumber {
+		m.Parser().AddOptions(parser.WithASTTransformers(
+			util.Prioritized(&renumberTransformer{}, 1001), // After the transformers that read source numbering
+		))
+	}
+	if len(opts.AttributeProviders) > 0 {
+		// After goldmark-attributes (100), ahead of listTypeTransformer
+		m.Parser().AddOptions(parser.WithASTTransformers(
+			util.Prioritized(&attributeProviderTransformer{opts.AttributeProviders}, 999),
+		))
+	}
+	if opts.Indent != "" {
+		m.Renderer().AddOptions(renderer.WithNodeRenderers(
+			util.Prioritized(&indentedParagraphHTMLRenderer{opts}, 500),
+		))
+	}
+	if opts.Regions != "" {
+		m.Parser().AddOptions(parser.WithBlockParsers(
+			util.Prioritized(&fancyRegionParser{[]byte(opts.Regions)}, 99), // Ahead of the list parsers
+		))
+		m.Renderer().AddOptions(renderer.WithNodeRenderers(
+			util.Prioritized(&fancyRegionHTMLRenderer{}, 500),
+		))
+	}
+	if opts.BlockAttributes {
+		m.Parser().AddOptions(
+			parser.WithBlockParsers(
+				util.Prioritized(&listAttributesParser{}, 99), // Ahead of goldmark-attributes (100)
+			),
+			parser.WithASTTransformers(
+				util.Prioritized(&listAttributesTransformer{}, 100),
+				util.Prioritized(&spacingTransformer{}, 1000),
+			),
+		)
+		m.Renderer().AddOptions(renderer.WithNodeRenderers(
+			util.Prioritized(&listAttributesHTMLRenderer{}, 500),
+		))
+	}
 }
 
 // parseListItem analyzes a line of text to determine if it contains a list item marker.
@@ -59,7 +402,9 @@ func parseListItem(line []byte) ([6]int, listItemType) {
 	i := 0
 	l := len(line)
 	ret := [6]int{}
-	for ; i < l && line[i] == ' '; i++ {
+	// Scanning stops as soon as a limit is exceeded so very long marker-like
+	// prefixes are rejected without reading the rest of the line.
+	for ; i < l && i <= 3 && line[i] == ' '; i++ {
 		c := line[i]
 		if c == '\t' {
 			return ret, notList
@@ -82,9 +427,20 @@ func parseListItem(line []byte) ([6]int, listItemType) {
 		// Check for ordered list markers (numbers, letters, roman numerals, '#')
 		start := i
 
-		// Handle '#' as a special marker for continuing lists
+		// Handle '#' as a special marker for continuing lists, optionally
+		// followed by a number or letters giving the item's value ('#5.')
 		if line[i] == '#' {
 			i++
+			hintStart := i
+			for ; i < l && i-hintStart <= 9 && util.IsNumeric(line[i]); i++ {
+			}
+			if i == hintStart {
+				for ; i < l && i-hintStart < maxMarkerLen && isASCIILetter(line[i]); i++ {
+				}
+			}
+			if i-hintStart > 9 {
+				return ret, notList
+			}
 			ret[3] = i
 			if i < l && (line[i] == '.' || line[i] == ')') {
 				i++
@@ -96,7 +452,7 @@ func parseListItem(line []byte) ([6]int, listItemType) {
 		} else {
 			// Check for numeric markers (1-9 digits)
 			numStart := i
-			for ; i < l && util.IsNumeric(line[i]); i++ {
+			for ; i < l && i-numStart <= 9 && util.IsNumeric(line[i]); i++ {
 			}
 			if i > numStart && i-numStart <= 9 {
 				// Found numeric marker
@@ -111,7 +467,7 @@ func parseListItem(line []byte) ([6]int, listItemType) {
 			} else {
 				// Check for alphabetic markers (letters only, 1-6 chars)
 				i = start
-				for ; i < l && i-start < 6 && unicode.IsLetter(rune(line[i])); i++ {
+				for ; i < l && i-start < maxMarkerLen && isASCIILetter(line[i]); i++ {
 				}
 				if i > start {
 					// Found alphabetic marker
@@ -132,6 +488,13 @@ func parseListItem(line []byte) ([6]int, listItemType) {
 		return ret, notList
 	}
 
+	return finishListItem(line, i, ret, typ)
+}
+
+// finishListItem checks what follows a marker ending at i and fills in the
+// content positions of ret.
+func finishListItem(line []byte, i int, ret [6]int, typ listItemType) ([6]int, listItemType) {
+	l := len(line)
 	if i < l && line[i] != '\n' {
 		w, _ := util.IndentWidth(line[i:], 0)
 		if w == 0 {
@@ -151,6 +514,30 @@ func parseListItem(line []byte) ([6]int, listItemType) {
 	return ret, typ
 }
 
+// mayBeListItem is a cheap pre-check run before full marker parsing. Every
+// letter is a trigger, so ordinary prose reaches the parsers constantly; a
+// line starting with a letter can only be a list item if a '.' or ')'
+// delimiter follows within maxMarkerLen bytes.
+func mayBeListItem(line []byte) bool {
+	i := 0
+	for i < len(line) && i <= 3 && line[i] == ' ' {
+		i++
+	}
+	if i >= len(line) || !isASCIILetter(line[i]) {
+		return true
+	}
+	end := i + maxMarkerLen + 1
+	if end > len(line) {
+		end = len(line)
+	}
+	for j := i + 1; j < end; j++ {
+		if line[j] == '.' || line[j] == ')' {
+			return true
+		}
+	}
+	return false
+}
+
 func matchesListItem(source []byte, strict bool) ([6]int, listItemType) {
 	m, typ := parseListItem(source)
 	if typ != notList && (!strict || strict && m[1] < 4) {
@@ -159,6 +546,186 @@ func matchesListItem(source []byte, strict bool) ([6]int, listItemType) {
 	return m, notList
 }
 
+// matchListItem is matchesListItem with the configured marker policies applied.
+func (e *FancyListsOptions) matchListItem(source []byte, strict bool) ([6]int, listItemType) {
+	if m, typ := e.matchPrefixedItem(source, strict); typ != notList {
+		return m, typ
+	}
+	if e.RelaxedIndent {
+		if n := leadingSpaces(source); n > 3 && n < relaxedMarkerIndent {
+			// Match the marker as if it were indented three spaces, then
+			// shift the positions back onto the full line
+			shift := n - 3
+			m, typ := e.matchMarker(source[shift:], strict)
+			for i := range m {
+				if m[i] >= 0 {
+					m[i] += shift
+				}
+			}
+			m[0] = 0
+			return m, typ
+		}
+	}
+	return e.matchMarker(source, strict)
+}
+
+// matchMarker matches a marker indented at most three spaces.
+func (e *FancyListsOptions) matchMarker(source []byte, strict bool) ([6]int, listItemType) {
+	m, typ := matchesListItem(source, strict)
+	if typ == notList && e.FullWidthMarkers {
+		m, typ = parseFullWidthListItem(source)
+	}
+	if typ == notList && e.ArabicIndicDigits {
+		m, typ = parseArabicListItem(source)
+	}
+	if typ == notList && e.UnicodeRoman {
+		m, typ = parseUnicodeRomanListItem(source)
+	}
+	if typ == notList && e.ParenthesizedMarkers {
+		m, typ = parseParenthesizedListItem(source)
+	}
+	if bullets := e.bullets(); typ == notList && bullets != "" {
+		m, typ = parseBulletListItem(source, bullets)
+	}
+	if typ == notList && e.OrdinalMarkers {
+		m, typ = parseOrdinalListItem(source)
+	}
+	if typ == notList && e.DoubleDelimiters {
+		m, typ = parseDoubleDelimiterListItem(source)
+	}
+	if typ == orderedListFancy && e.MixedCase == MixedCaseReject && isMixedCase(markerLetters(markerText(source, m))) {
+		return m, notList
+	}
+	if typ == orderedListFancy && !e.inAlphabet(markerText(source, m)) {
+		return m, notList
+	}
+	if typ == orderedListFancy && e.isAbbreviation(source[m[2]:m[3]]) {
+		return m, notList
+	}
+	if typ == bulletList && e.BulletPassthrough && markerBullet(source, m) == nil {
+		return m, notList
+	}
+	if typ == orderedList && !e.typeEnabled("1") {
+		return m, notList
+	}
+	if typ == orderedListFancy && !e.lettersEnabled(markerLetters(markerText(source, m))) {
+		return m, notList
+	}
+	return m, typ
+}
+
+// leadingSpaces counts the spaces at the start of line.
+func leadingSpaces(line []byte) int {
+	n := 0
+	for n < len(line) && line[n] == ' ' {
+		n++
+	}
+	return n
+}
+
+// matchBareHashItem matches a "# item" marker, a '#' followed by whitespace
+// and content, when BareHashMarkers is enabled. It is only tried for lines
+// directly continuing an open ordered list, so a bare '#' never starts a
+// list and ATX headings elsewhere, or after a blank line, are unaffected.
+// The matched marker ends with the '#' itself, which markerDelimiter
+// reports as the delimiter.
+func (e *FancyListsOptions) matchBareHashItem(line []byte) ([6]int, listItemType) {
+	ret := [6]int{}
+	if !e.BareHashMarkers {
+		return ret, notList
+	}
+	i := 0
+	for i < len(line) && i <= 3 && line[i] == ' ' {
+		i++
+	}
+	if i > 3 || i+1 >= len(line) || line[i] != '#' || (line[i+1] != ' ' && line[i+1] != '\t') {
+		return ret, notList
+	}
+	ret[1] = i
+	ret[2] = i
+	ret[3] = i + 1
+	ret, typ := finishListItem(line, i+1, ret, orderedListFancy)
+	if typ == notList || ret[4] < 0 || util.IsBlank(line[ret[4]:ret[5]]) {
+		return ret, notList
+	}
+	return ret, typ
+}
+
+// hashHint splits a '#' marker into its optional value hint ("5" for "#5",
+// "" for "#"). It reports false for markers not starting with '#'.
+func hashHint(marker []byte) ([]byte, bool) {
+	if len(marker) == 0 || marker[0] != '#' {
+		return nil, false
+	}
+	return marker[1:], true
+}
+
+// markerLetters returns the letters of a marker, without the '#' of a hint.
+func markerLetters(marker []byte) []byte {
+	if hint, ok := hashHint(marker); ok {
+		return hint
+	}
+	return marker
+}
+
+// hintValue returns the item value given by the hint of a '#5.' or '#c.'
+// marker in a list of type typ, or 0 if the hint is out of range. Values
+// above MaxStart are out of range with StartLimitReject and lowered to it
+// with StartLimitClamp, as start values are.
+func (e *FancyListsOptions) hintValue(hint []byte, typ string) int {
+	value := e.uncappedHintValue(hint, typ)
+	if limit := e.MaxStart; limit > 0 && value > limit {
+		if e.MaxStartPolicy == StartLimitReject {
+			return 0
+		}
+		return limit
+	}
+	return value
+}
+
+// uncappedHintValue is hintValue without MaxStart. Letters are read as a
+// roman numeral in roman lists and alphabetically otherwise.
+func (e *FancyListsOptions) uncappedHintValue(hint []byte, typ string) int {
+	if util.IsNumeric(hint[0]) {
+		value := 0
+		for _, c := range hint {
+			value = value*10 + int(c-'0')
+		}
+		return value
+	}
+	if typ == "i" || typ == "I" {
+		if value, ok := anyRomanToNumber(hint); ok {
+			if value <= maxRomanValue || e.LargeRoman == LargeRomanAccept {
+				return value
+			}
+			if e.LargeRoman == LargeRomanReject || len(hint) > maxLargeRomanLetters {
+				return 0
+			}
+		}
+	}
+	return e.alphabetValue(hint)
+}
+
+// itemValue returns the number recorded on a list item, or 0.
+func itemValue(n ast.Node) int {
+	if v, ok := n.Attribute(attrNameValue); ok {
+		if value, ok := v.(int); ok {
+			return value
+		}
+	}
+	return 0
+}
+
+// isMixedCase reports whether a letter marker mixes upper and lower case, as in "Ii" or "xIv".
+func isMixedCase(marker []byte) bool {
+	for i := 1; i < len(marker); i++ {
+		if isLowerASCII(marker[i]) != isLowerASCII(marker[0]) {
+			return true
+		}
+	}
+	return false
+}
+
 func calcListOffset(source []byte, match [6]int) int {
 	var offset int
 	if match[4] < 0 || util.IsBlank(source[match[4]:]) { // list item starts with a blank line
@@ -172,102 +739,236 @@ func calcListOffset(source []byte, match [6]int) int {
 	return offset
 }
 
+// listType returns the type computed from a list's first marker as one of the
+// constant type strings ("1", "a", "A", "i", "I") without allocating. Lists
+// without a recorded type are numeric.
+func listType(node ast.Node) string {
+	v, ok := node.Attribute(attrNameListType)
+	if !ok {
+		return "1"
+	}
+	return typeString(v)
+}
+
+// typeString returns a type attribute value stored as []byte or string as one
+// of the constant type strings. Unrecognized values are numeric.
+func typeString(v interface{}) string {
+	var c byte
+	switch t := v.(type) {
+	case []byte:
+		if len(t) == 1 {
+			c = t[0]
+		}
+	case string:
+		if len(t) == 1 {
+			c = t[0]
+		}
+	}
+	switch c {
+	case 'a':
+		return "a"
+	case 'A':
+		return "A"
+	case 'i':
+		return "i"
+	case 'I':
+		return "I"
+	}
+	return "1"
+}
+
+// isTypeValue reports whether a type attribute value stored as []byte or
+// string is one of the ordered list types HTML defines.
+func isTypeValue(v interface{}) bool {
+	switch t := v.(type) {
+	case []byte:
+		return string(t) == typeString(t)
+	case string:
+		return t == typeString(t)
+	}
+	return false
+}
+
+// inheritedListType returns the type of the nearest ordered list before a
+// new list nested in parent: first among parent's earlier children, then in
+// the items before parent. It returns "1" when parent is not a list item or
+// there is no such list.
+func inheritedListType(parent ast.Node) string {
+	if _, ok := parent.(*ast.ListItem); !ok {
+		return "1"
+	}
+	for item := parent; item != nil; item = item.PreviousSibling() {
+		for c := item.LastChild(); c != nil; c = c.PreviousSibling() {
+			if list, ok := c.(*ast.List); ok && list.IsOrdered() {
+				return listType(list)
+			}
+		}
+	}
+	return "1"
+}
+
+// typeValue returns the interned type attribute value for a type string, or
+// nil for numeric lists.
+func typeValue(typ string) []byte {
+	switch typ {
+	case "a":
+		return typeLowerAlpha
+	case "A":
+		return typeUpperAlpha
+	case "i":
+		return typeLowerRoman
+	case "I":
+		return typeUpperRoman
+	}
+	return nil
+}
+
+// maxNestingDepth is the deepest block nesting at which a new list is opened.
+// Goldmark re-measures the line offset for every block opened on a line, so
+// thousands of markers on one line would otherwise take quadratic time.
+const maxNestingDepth = 100
+
+// exceedsMaxNestingDepth reports whether parent is nested more than
+// maxNestingDepth blocks deep. It stops walking once the limit is reached.
+func exceedsMaxNestingDepth(parent ast.Node) bool {
+	depth := 0
+	for n := parent; n != nil; n = n.Parent() {
+		depth++
+		if depth > maxNestingDepth {
+			return true
+		}
+	}
+	return false
+}
+
 func lastOffset(node ast.Node) int {
-	lastChild := node.LastChild()
-	if lastChild != nil {
-		return lastChild.(*ast.ListItem).Offset
+	if item, ok := node.LastChild().(*ast.ListItem); ok {
+		return item.Offset
 	}
 	return 0
 }
 
-// Helper functions for converting alphabetic and roman numeral markers to numbers
+// Helper functions for converting alphabetic and roman numeral markers to numbers.
+// They operate directly on the marker bytes so classifying a line does not allocate.
+
+// maxMarkerLen is the longest alphabetic or roman marker parseListItem accepts.
+const maxMarkerLen = 6
+
+func isASCIILetter(c byte) bool {
+	return (c|0x20) >= 'a' && (c|0x20) <= 'z'
+}
 
-func getListTypeFromMarker(markerBytes []byte, typ listItemType) (string, string) {
-	marker := string(markerBytes)
+func isLowerASCII(c byte) bool {
+	return c >= 'a' && c <= 'z'
+}
 
+func getListTypeFromMarker(marker []byte, typ listItemType) (string, string) {
 	if typ == orderedList {
-		return "1", "fl-num"
+		return "1", ClassNumeric
 	}
 
 	if typ == orderedListFancy {
-		if marker == "#" {
+		if len(marker) == 1 && marker[0] == '#' {
 			// For '#' marker, we default to numeric unless context suggests otherwise
-			return "1", "fl-num"
+			return "1", ClassNumeric
 		} else if len(marker) > 0 {
 			// Check if it's a roman numeral first (must start with 'i' or 'I')
 			if marker[0] == 'i' || marker[0] == 'I' {
 				if _, ok := romanToNumber(marker); ok {
-					if unicode.IsLower(rune(marker[0])) {
-						return "i", "fl-lcroman"
+					if isLowerASCII(marker[0]) {
+						return "i", ClassLowerRoman
 					} else {
-						return "I", "fl-ucroman"
+						return "I", ClassUpperRoman
 					}
 				}
 			}
 			// Otherwise it's alphabetic
-			if unicode.IsLower(rune(marker[0])) {
-				return "a", "fl-lcalpha"
+			if isLowerASCII(marker[0]) {
+				return "a", ClassLowerAlpha
 			} else {
-				return "A", "fl-ucalpha"
+				return "A", ClassUpperAlpha
 			}
 		}
 	}
 
 	// Default fallback
-	return "1", "fl-num"
+	return "1", ClassNumeric
 }
 
-func alphabeticToNumber(s string) int {
+// maxStartValue is the largest start value a marker may produce. It matches the
+// nine-digit limit CommonMark places on numeric markers and fits in a 32-bit int.
+const maxStartValue = 999999999
+
+// alphabeticToNumber converts an alphabetic marker to its start value
+// (a=1, z=26, aa=27). It returns 0 for invalid input or when the value would
+// exceed maxStartValue, so callers reject the marker instead of overflowing.
+func alphabeticToNumber(s []byte) int {
 	if len(s) == 0 {
 		return 0
 	}
 
-	s = strings.ToLower(s)
-
 	result := 0
 	base := 26
 
-	for i, char := range s {
-		if char < 'a' || char > 'z' {
+	for _, c := range s {
+		c |= 0x20 // fold ASCII letters to lowercase
+		if c < 'a' || c > 'z' {
 			return 0 // Invalid character
 		}
-		digit := int(char - 'a' + 1)
-		if i == len(s)-1 {
-			result += digit
-		} else {
-			result += digit * pow(base, len(s)-1-i)
+		digit := int(c - 'a' + 1)
+		if result > (maxStartValue-digit)/base {
+			return 0 // Out of range
 		}
+		result = result*base + digit
 	}
 
 	return result
 }
 
-func pow(base, exp int) int {
-	result := 1
-	for exp > 0 {
-		result *= base
-		exp--
-	}
-	return result
+// romanUpper maps the lowercase roman numeral letters to uppercase; every
+// other byte maps to zero so invalid input is rejected by the lookup alone.
+var romanUpper = [256]byte{
+	'i': 'I', 'v': 'V', 'x': 'X', 'l': 'L', 'c': 'C', 'd': 'D', 'm': 'M',
+	'I': 'I', 'V': 'V', 'X': 'X', 'L': 'L', 'C': 'C', 'D': 'D', 'M': 'M',
 }
 
-func romanToNumber(s string) (int, bool) {
-	// Check if it starts with valid roman numeral pattern
-	if len(s) == 0 {
-		return 0, false
-	}
-
+func romanToNumber(s []byte) (int, bool) {
 	// Only support roman numerals starting with 'i' (case insensitive)
 	// This means: i, ii, iii, iv (lowercase) or I, II, III, IV (uppercase)
 	// But NOT: vi, vii, etc. (those are treated as alphabetic)
-	first := strings.ToLower(s)[0]
-	if first != 'i' {
+	if len(s) == 0 || (s[0] != 'i' && s[0] != 'I') {
+		return 0, false
+	}
+	return anyRomanToNumber(s)
+}
+
+// maxRomanValue is the largest value written with classical roman numerals,
+// MMMCMXCIX.
+const maxRomanValue = 3999
+
+// maxLargeRomanLetters is the length of the longest numeral above
+// maxRomanValue that LargeRomanAlpha reads as a letter marker. "MMMM." is
+// one; the alphabetic value of longer numerals such as "MMMMI." is in the
+// millions, so they are not list markers.
+const maxLargeRomanLetters = 4
+
+// anyRomanToNumber converts a roman numeral of any form, including those not
+// starting with 'i' that romanToNumber leaves to the alphabetic reading.
+func anyRomanToNumber(s []byte) (int, bool) {
+	if len(s) == 0 || len(s) > maxMarkerLen {
 		return 0, false
 	}
 
-	// Convert to uppercase for parsing since romannumeral library expects uppercase
-	upperS := strings.ToUpper(s)
-	num, err := romannumeral.StringToInt(upperS)
+	// Convert to uppercase for parsing since romannumeral library expects uppercase.
+	// The buffer stays on the stack because the marker length is bounded.
+	var buf [maxMarkerLen]byte
+	upper := buf[:len(s)]
+	for i, c := range s {
+		if upper[i] = romanUpper[c]; upper[i] == 0 {
+			return 0, false
+		}
+	}
+	num, err := romannumeral.BytesToInt(upper)
 	if err != nil {
 		return 0, false
 	}
@@ -275,103 +976,254 @@ func romanToNumber(s string) (int, bool) {
 	return num, true
 }
 
-type fancyListParser struct{}
-
-func (b *fancyListParser) Trigger() []byte {
-	// Include all possible list markers: bullets, numbers, letters, and hash
-	triggers := []byte{'-', '+', '*', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9', '#'}
+// listTriggers holds the ASCII bytes that may start a list marker: bullets,
+// numbers, letters, and hash. triggers adds the lead bytes of the non-ASCII
+// markers the options enable.
+var listTriggers = [...]byte{
+	'-', '+', '*', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9', '#',
+	'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j', 'k', 'l', 'm',
+	'n', 'o', 'p', 'q', 'r', 's', 't', 'u', 'v', 'w', 'x', 'y', 'z',
+	'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M',
+	'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z',
+}
 
-	// Add all letters
-	for c := 'a'; c <= 'z'; c++ {
-		triggers = append(triggers, byte(c))
-	}
-	for c := 'A'; c <= 'Z'; c++ {
-		triggers = append(triggers, byte(c))
-	}
+type fancyListParser struct {
+	options FancyListsOptions
+}
 
-	return triggers
+func (b *fancyListParser) Trigger() []byte {
+	return b.options.triggers()
 }
 
 func (b *fancyListParser) Open(parent ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	state := stateOf(pc)
 	last := pc.LastOpenedBlock().Node
-	if _, lok := last.(*ast.List); lok || pc.Get(skipListParserKey) != nil {
-		pc.Set(skipListParserKey, nil)
+	if _, lok := last.(*ast.List); lok || state.skipListParser {
+		state.skipListParser = false
+		return nil, parser.NoChildren
+	}
+	if state.disabled {
 		return nil, parser.NoChildren
 	}
 	line, _ := reader.PeekLine()
-	match, typ := matchesListItem(line, true)
+	if !b.options.mayBeMarker(line) {
+		return nil, parser.NoChildren
+	}
+	if exceedsMaxNestingDepth(parent) {
+		b.options.trace(reader, TraceNotList, "maximum nesting depth reached")
+		return nil, parser.NoChildren
+	}
+	match, typ := b.options.matchListItemIn(parent, line, true)
 	if typ == notList {
+		b.options.trace(reader, TraceNotList, "no list marker")
 		return nil, parser.NoChildren
 	}
 
 	start := -1
-	var fltype *string
+	var fltype []byte
+	seed, seeded := pendingCounter(pc, parent)
+	if typ == bulletList {
+		seeded = false
+	}
 
 	switch typ {
 	case orderedList:
-		number := line[match[2] : match[3]-1]
-		start, _ = strconv.Atoi(string(number))
+		number := markerText(line, match)
+		start = 0
+		for _, c := range number {
+			start = start*10 + int(c-'0')
+		}
 	case orderedListFancy:
-		number := line[match[2] : match[3]-1]
+		number := markerText(line, match)
+		if hint, ok := hashHint(number); ok {
+			// A list opened by '#5.' or '#c.' starts like '5.' or 'c.'
+			number = hint
+		}
 
-		if string(number) == "#" {
+		if len(number) == 0 {
 			// For '#' marker, we'll determine type from context or default to numeric
 			start = 1 // Default start
-			// fltype remains nil for default behavior
+			if len(b.options.HashDepthTypes) > 0 {
+				// The type comes from the configured cycle by nesting depth
+				fltype = typeValue(b.options.hashDepthType(listDepth(parent)))
+			} else {
+				// A nested list takes the type of the nearest earlier list at its depth
+				fltype = typeValue(inheritedListType(parent))
+			}
+			if seeded {
+				// The first list of a fragment continues the seeded counter
+				start, fltype = seed.Next, typeValue(seed.Type)
+			}
+		} else if util.IsNumeric(number[0]) {
+			start = 0
+			for _, c := range number {
+				start = start*10 + int(c-'0')
+			}
+			if start == 0 {
+				// The item parser rejects '#0.', which gives no item value
+				b.options.trace(reader, TraceNotList, "value hint of zero")
+				return nil, parser.NoChildren
+			}
 		} else {
 			// Check if it's a roman numeral first (must start with 'i' or 'I')
-			if len(number) > 0 && (number[0] == 'i' || number[0] == 'I') {
-				if romanNum, ok := romanToNumber(string(number)); ok {
-					start = romanNum
-					if unicode.IsLower(rune(number[0])) {
-						fltype = &[]string{"i"}[0]
-					} else {
-						fltype = &[]string{"I"}[0]
+			roman := len(number) > 0 && (number[0] == 'i' || number[0] == 'I')
+			romanNum, romanOK := 0, false
+			if isUnicodeRomanMarker(line, match) {
+				// Roman numeral characters such as 'Ⅻ' are never letters
+				romanNum, romanOK = anyRomanToNumber(number)
+				roman = true
+			} else if b.options.romanPrefix(markerPrefix(line, match)) {
+				// Legal prefixes such as 'Article ' read roman numerals first
+				romanNum, romanOK = anyRomanToNumber(number)
+				roman = romanOK
+			} else if value, ok := b.options.ambiguousMarkerValue(number); ok {
+				// A configured preference decides standalone i/v/x markers
+				roman = b.options.AmbiguousMarkers == AmbiguousRoman
+				romanNum, romanOK = value, roman
+			} else if roman {
+				romanNum, romanOK = romanToNumber(number)
+			} else if value, ok := anyRomanToNumber(number); ok && value > maxRomanValue {
+				// Numerals such as 'MMMMI' can only be large roman numerals
+				roman, romanNum, romanOK = true, value, true
+			}
+			romanType, alphaType := "i", "a"
+			if !isLowerASCII(number[0]) {
+				romanType, alphaType = "I", "A"
+			}
+			if roman && !b.options.typeEnabled(romanType) {
+				// Only the alphabetic reading is enabled
+				roman = false
+			} else if !roman && !b.options.typeEnabled(alphaType) {
+				// Only the roman reading is enabled
+				roman = true
+				romanNum, romanOK = anyRomanToNumber(number)
+			}
+			if roman && romanOK && romanNum > maxRomanValue {
+				// Numerals beyond the classical range follow the configured policy
+				switch b.options.LargeRoman {
+				case LargeRomanAlpha:
+					if len(number) > maxLargeRomanLetters {
+						b.options.trace(reader, TraceNotList, "roman numeral above 3999 too long for a letter marker")
+						return nil, parser.NoChildren
 					}
-				} else {
+					roman = false
+				case LargeRomanReject:
+					b.options.trace(reader, TraceNotList, "roman numeral above 3999")
 					return nil, parser.NoChildren
 				}
-			} else if unicode.IsLetter(rune(number[0])) {
+			}
+			if roman {
+				if !romanOK {
+					b.options.trace(reader, TraceNotList, "invalid roman numeral")
+					return nil, parser.NoChildren
+				}
+				start = romanNum
+				if isLowerASCII(number[0]) {
+					fltype = typeLowerRoman
+				} else {
+					fltype = typeUpperRoman
+				}
+			} else if isASCIILetter(number[0]) {
 				// Alphabetic marker
-				start = alphabeticToNumber(string(number))
+				start = b.options.alphabetValue(number)
 				if start == 0 {
+					b.options.trace(reader, TraceNotList, "letter marker out of range")
 					return nil, parser.NoChildren
 				}
-				if unicode.IsLower(rune(number[0])) {
-					fltype = &[]string{"a"}[0]
+				if isLowerASCII(number[0]) {
+					fltype = typeLowerAlpha
 				} else {
-					fltype = &[]string{"A"}[0]
+					fltype = typeUpperAlpha
 				}
 			}
 		}
 	}
 
+	if typ != bulletList && !b.options.typeEnabled(string(fltype)) {
+		b.options.trace(reader, TraceNotList, "marker type disabled")
+		return nil, parser.NoChildren
+	}
+
+	// Apply the configured cap on start values. Goldmark's core parser would
+	// open a rejected CommonMark marker uncapped, so those are clamped
+	if limit := b.options.MaxStart; limit > 0 && start > limit {
+		if b.options.MaxStartPolicy == StartLimitReject && !isCommonMarkMarker(line, match, typ) {
+			b.options.trace(reader, TraceNotList, "start above the configured maximum")
+			return nil, parser.NoChildren
+		}
+		start = limit
+	}
+
 	if ast.IsParagraph(last) && last.Parent() == parent {
 		// we allow only lists starting with 1 to interrupt paragraphs,
 		// but this restriction doesn't apply to nested lists (inside list items)
 		if _, isListItem := parent.(*ast.ListItem); !isListItem {
-			if typ == orderedList && start != 1 {
-				return nil, parser.NoChildren
-			}
-			if typ == orderedListFancy && start != 1 {
+			if typ == orderedList && start != 1 && b.options.Interrupt != InterruptAny ||
+				typ == orderedListFancy && start != 1 && b.options.Interrupt == InterruptFromOne {
+				b.options.trace(reader, TraceNotList, "a list not starting at 1 cannot interrupt a paragraph")
 				return nil, parser.NoChildren
 			}
 		}
 		//an empty list item cannot interrupt a paragraph:
 		if match[4] < 0 || util.IsBlank(line[match[4]:match[5]]) {
+			b.options.trace(reader, TraceNotList, "an empty item cannot interrupt a paragraph")
 			return nil, parser.NoChildren
 		}
 	}
 
-	marker := line[match[3]-1]
+	if seeded {
+		pc.Get(counterKey).(*counterState).pending = false
+	}
+
+	marker := markerDelimiter(line, match)
 	node := ast.NewList(marker)
 	if start > -1 {
 		node.Start = start
 	}
 	if fltype != nil {
-		node.SetAttribute([]byte("type"), []byte(*fltype))
+		node.SetAttribute(attrNameListType, fltype)
+		addTypedList(pc, node)
+	}
+	if number := markerText(line, match); typ == orderedList && len(number) > 1 && number[0] == '0' {
+		// Record the width of zero-padded markers such as '003.'
+		node.SetAttribute(attrNamePadding, len(number))
+	}
+	if prefix := markerPrefix(line, match); prefix != nil {
+		// Record the prefix of markers such as 'Step 1.'
+		node.SetAttribute(attrNamePrefix, append([]byte(nil), prefix...))
+	}
+	if suffix := ordinalSuffix(line, match); suffix != nil {
+		// Record the suffix of ordinal markers such as '1º'
+		node.SetAttribute(attrNameSuffix, append([]byte(nil), suffix...))
+	}
+	if bullet := markerBullet(line, match); bullet != nil {
+		// Record the bullet of markers such as '•'
+		node.SetAttribute(attrNameBullet, append([]byte(nil), bullet...))
+	}
+	if delim := fullWidthDelimiter(line, match); delim != nil {
+		// Record the original delimiter of full-width markers such as '（a）'
+		// and of double delimiters such as '1.)'
+		node.SetAttribute(attrNameDelimiter, delim)
+	}
+	if typ == orderedList {
+		if digits := markerDigits(line, match); digits != nil {
+			// Record the digit script of Arabic-Indic markers such as '١.'
+			node.SetAttribute(attrNameDigits, digits)
+		}
 	}
-	pc.Set(emptyListItemWithBlankLines, nil)
+	node.SetAttribute(attrNameMarker, markerSegment(reader, match))
+	if b.options.Offsets == OffsetsLenient {
+		node.SetAttribute(attrNameColumn, reader.LineOffset())
+	}
+	state.emptyItemBlankLine = false
+	if b.options.Trace != nil {
+		if typ == bulletList {
+			b.options.trace(reader, TraceList, "bullet list")
+		} else {
+			b.options.trace(reader, TraceList, "ordered list of type %s starting at %d", listType(node), node.Start)
+		}
+	}
+	state.lists = append(state.lists, node)
 	return node, parser.HasChildren
 }
 
@@ -379,8 +1231,12 @@ func (b *fancyListParser) Continue(node ast.Node, reader text.Reader, pc parser.
 	list := node.(*ast.List)
 	line, _ := reader.PeekLine()
 	if util.IsBlank(line) {
+		if b.options.endsAtBlankLines(reader, pc) {
+			b.options.trace(reader, TraceClose, "two blank lines end the list")
+			return parser.Close
+		}
 		if node.LastChild().ChildCount() == 0 {
-			pc.Set(emptyListItemWithBlankLines, listItemFlagValue)
+			stateOf(pc).emptyItemBlankLine = true
 		}
 		return parser.Continue | parser.HasChildren
 	}
@@ -388,48 +1244,96 @@ func (b *fancyListParser) Continue(node ast.Node, reader text.Reader, pc parser.
 	offset := lastOffset(node)
 	lastIsEmpty := node.LastChild().ChildCount() == 0
 	indent, _ := util.IndentWidth(line, reader.LineOffset())
+	delta := b.options.columnDelta(list, reader)
+	indent += delta
 
 	if indent < offset || lastIsEmpty {
-		if indent < 4 {
-			match, typ := matchesListItem(line, false)
-			if typ != notList && match[1]-offset < 4 {
-				marker := line[match[3]-1]
+		if indent < b.options.markerIndent() {
+			match, typ := b.options.matchListItemIn(node, line, false)
+			if typ == notList {
+				match, typ = b.options.matchBareHashItemIn(node, reader, line)
+			}
+			if typ != notList && match[1]+delta-offset < b.options.markerIndent() {
+				marker := markerDelimiter(line, match)
+				if marker == '#' {
+					// A bare '#' continues the list whatever its delimiter
+					marker = list.Marker
+				}
 
 				// Check if the list can continue with this marker type
 				if !list.CanContinue(marker, typ == orderedList || typ == orderedListFancy) {
+					b.options.trace(reader, TraceClose, "delimiter or bullet changed")
+					return parser.Close
+				}
+				// A prefixed list continues only with the same prefix or '#'
+				prefix := markerPrefix(line, match)
+				if !bytes.EqualFold(prefix, listPrefix(list)) && (prefix != nil || markerText(line, match)[0] != '#') {
+					b.options.trace(reader, TraceClose, "marker prefix changed")
+					return parser.Close
+				}
+				// An ordinal list continues only with the same suffix or '#'
+				if !sameSuffix(list, line, match) && markerText(line, match)[0] != '#' {
+					b.options.trace(reader, TraceClose, "ordinal suffix changed")
+					return parser.Close
+				}
+				// A list of configured bullets continues only with the same bullet
+				if !sameBullet(list, line, match) {
+					b.options.trace(reader, TraceClose, "bullet changed")
 					return parser.Close
 				}
 
 				// For ordered lists, check if the type has changed
 				if typ == orderedList || typ == orderedListFancy {
-					markerBytes := line[match[2] : match[3]-1]
-					markerStr := string(markerBytes)
+					markerBytes := markerText(line, match)
+
+					// The item parser rejects hints without a valid value, such as '#0.'
+					if hint, ok := hashHint(markerBytes); ok && len(hint) > 0 && b.options.hintValue(hint, listType(list)) == 0 {
+						b.options.trace(reader, TraceClose, "value hint out of range")
+						return parser.Close
+					}
 
 					// If it's a '#' marker, it should continue the current list type
-					if markerStr != "#" {
+					if markerBytes[0] != '#' {
 						// Get current list type
-						currentType := "1" // default
-						if currentTypeAttr, ok := list.AttributeString("type"); ok {
-							if typeBytes, ok := currentTypeAttr.([]byte); ok {
-								currentType = string(typeBytes)
-							} else if typeStr, ok := currentTypeAttr.(string); ok {
-								currentType = typeStr
-							}
-						}
+						currentType := listType(list)
 
 						// For specific markers (non-#), determine expected type with context awareness
 						var expectedType string
 
-						// Handle the ambiguous case of 'i'/'I'
-						if len(markerStr) == 1 && (markerStr == "i" || markerStr == "I") {
+						romanValue, romanOK := anyRomanToNumber(markerBytes)
+						if romanOK && romanValue > maxRomanValue && b.options.LargeRoman != LargeRomanAccept {
+							if b.options.LargeRoman == LargeRomanReject {
+								b.options.trace(reader, TraceClose, "roman numeral above 3999")
+								return parser.Close
+							}
+							if len(markerBytes) > maxLargeRomanLetters {
+								b.options.trace(reader, TraceClose, "roman numeral above 3999 too long for a letter marker")
+								return parser.Close
+							}
+							// Read as a letter marker below
+							romanOK = false
+						}
+
+						if romanOK && (romanValue > maxRomanValue || b.options.romanPrefix(markerPrefix(line, match)) || isUnicodeRomanMarker(line, match)) {
+							// Large numerals, legal prefixes such as 'Article ' and
+							// roman numeral characters read roman numerals first
+							expectedType = "I"
+							if isLowerASCII(markerBytes[0]) {
+								expectedType = "i"
+							}
+						} else if _, ok := b.options.ambiguousMarkerValue(markerBytes); ok {
+							// A configured preference decides standalone i/v/x markers
+							expectedType = b.options.ambiguousMarkerType(markerBytes[0])
+						} else if len(markerBytes) == 1 && (markerBytes[0] == 'i' || markerBytes[0] == 'I') {
+							// Handle the ambiguous case of 'i'/'I'
 							// If current list is alphabetic AND same case, treat 'i'/'I' as alphabetic
 							// If current list is different case alphabetic, numeric, or roman, treat 'i'/'I' as roman
-							if (currentType == "a" && markerStr == "i") || (currentType == "A" && markerStr == "I") {
+							if (currentType == "a" && markerBytes[0] == 'i') || (currentType == "A" && markerBytes[0] == 'I') {
 								// Same case alphabetic - continue as alphabetic
 								expectedType = currentType
 							} else {
 								// Different case, numeric, or roman - treat as roman numeral
-								if markerStr == "i" {
+								if markerBytes[0] == 'i' {
 									expectedType = "i"
 								} else {
 									expectedType = "I"
@@ -439,9 +1343,13 @@ func (b *fancyListParser) Continue(node ast.Node, reader text.Reader, pc parser.
 							// For non-ambiguous cases, use normal logic
 							expectedType, _ = getListTypeFromMarker(markerBytes, typ)
 						}
+						expectedType = b.options.enabledType(expectedType)
 
 						// If types don't match, close this list to start a new one
 						if expectedType != currentType {
+							if b.options.Trace != nil {
+								b.options.trace(reader, TraceClose, "marker type changed from %s to %s", currentType, expectedType)
+							}
 							return parser.Close
 						}
 					}
@@ -452,15 +1360,19 @@ func (b *fancyListParser) Continue(node ast.Node, reader text.Reader, pc parser.
 			}
 		}
 		if !lastIsEmpty {
+			b.options.refuseLazyLine(reader, pc)
+			b.options.trace(reader, TraceClose, "line is not indented to the item content")
 			return parser.Close
 		}
 	}
 
 	if lastIsEmpty && indent < offset {
+		b.options.trace(reader, TraceClose, "line is not indented to the item content")
 		return parser.Close
 	}
 
-	if pc.Get(emptyListItemWithBlankLines) != nil {
+	if stateOf(pc).emptyItemBlankLine {
+		b.options.trace(reader, TraceClose, "an empty item followed by a blank line ends the list")
 		return parser.Close
 	}
 	return parser.Continue | parser.HasChildren
@@ -468,6 +1380,10 @@ func (b *fancyListParser) Continue(node ast.Node, reader text.Reader, pc parser.
 
 func (b *fancyListParser) Close(node ast.Node, reader text.Reader, pc parser.Context) {
 	list := node.(*ast.List)
+	if b.options.Reversed {
+		b.options.markReversed(list, reader.Source())
+	}
+	recordCounter(pc, list)
 
 	for c := node.FirstChild(); c != nil && list.IsTight; c = c.NextSibling() {
 		if c.FirstChild() != nil && c.FirstChild() != c.LastChild() {
@@ -505,24 +1421,15 @@ func (b *fancyListParser) CanInterruptParagraph() bool {
 }
 
 func (b *fancyListParser) CanAcceptIndentedLine() bool {
-	return false
+	return b.options.RelaxedIndent
 }
 
-type fancyListItemParser struct{}
+type fancyListItemParser struct {
+	options FancyListsOptions
+}
 
 func (b *fancyListItemParser) Trigger() []byte {
-	// Include all possible list markers: bullets, numbers, letters, and hash
-	triggers := []byte{'-', '+', '*', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9', '#'}
-
-	// Add all letters
-	for c := 'a'; c <= 'z'; c++ {
-		triggers = append(triggers, byte(c))
-	}
-	for c := 'A'; c <= 'Z'; c++ {
-		triggers = append(triggers, byte(c))
-	}
-
-	return triggers
+	return b.options.triggers()
 }
 
 func (b *fancyListItemParser) Open(parent ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
@@ -530,25 +1437,68 @@ func (b *fancyListItemParser) Open(parent ast.Node, reader text.Reader, pc parse
 	if !lok { // list item must be a child of a list
 		return nil, parser.NoChildren
 	}
-	offset := lastOffset(list)
+	if _, ours := list.Attribute(attrNameMarker); !ours {
+		// Items of the lists Goldmark's core parser opened are its own
+		return nil, parser.NoChildren
+	}
+	state := stateOf(pc)
+	if state.disabled {
+		return nil, parser.NoChildren
+	}
 	line, _ := reader.PeekLine()
-	match, typ := matchesListItem(line, false)
+	if !b.options.mayBeMarker(line) {
+		return nil, parser.NoChildren
+	}
+	offset := lastOffset(list)
+	match, typ := b.options.matchListItemIn(list, line, false)
+	if typ == notList && list.IsOrdered() {
+		match, typ = b.options.matchBareHashItemIn(list, reader, line)
+	}
 	if typ == notList {
 		return nil, parser.NoChildren
 	}
-	if match[1]-offset > 3 {
+	if match[1]-offset >= b.options.markerIndent() {
 		return nil, parser.NoChildren
 	}
 
-	pc.Set(emptyListItemWithBlankLines, nil)
+	state.emptyItemBlankLine = false
+
+	if typ == orderedListFancy && b.options.MixedCase == MixedCaseDiagnose && isMixedCase(markerLetters(markerText(line, match))) {
+		addDiagnostic(pc, reader, match, line, "marker mixes upper and lower case; normalized to the case of its first letter")
+	}
 
 	itemOffset := calcListOffset(line, match)
-	node := ast.NewListItem(match[3] + itemOffset)
+	contentOffset := markerWidth(line, match) + itemOffset
+	if b.options.HangingIndent > 0 {
+		// Continuation content aligns to a fixed indent from the marker
+		contentOffset = match[1] + b.options.HangingIndent
+	}
+	node := ast.NewListItem(contentOffset)
+	node.SetAttribute(attrNameMarker, markerSegment(reader, match))
 
 	// Set the value attribute for fancy lists
 	if typ == orderedList || typ == orderedListFancy {
-		itemNumber := list.ChildCount() + list.Start
-		node.SetAttribute([]byte("value"), []byte(strconv.Itoa(itemNumber)))
+		itemNumber := list.Start
+		if prev, ok := list.LastChild().(*ast.ListItem); ok {
+			itemNumber = itemValue(prev) + 1
+		}
+		if hint, ok := hashHint(markerText(line, match)); ok && len(hint) > 0 {
+			// '#5.' and '#c.' set the value explicitly
+			value := b.options.hintValue(hint, listType(list))
+			if value == 0 {
+				return nil, parser.NoChildren
+			}
+			if value != itemNumber {
+				node.SetAttribute(attrNameExplicit, true)
+			}
+			itemNumber = value
+		}
+		node.SetAttribute(attrNameValue, itemNumber)
+		if b.options.Trace != nil {
+			b.options.trace(reader, TraceItem, "item %d", itemNumber)
+		}
+	} else {
+		b.options.trace(reader, TraceItem, "bullet item")
 	}
 
 	if match[4] < 0 || util.IsBlank(line[match[4]:match[5]]) {
@@ -569,20 +1519,26 @@ func (b *fancyListItemParser) Continue(node ast.Node, reader text.Reader, pc par
 	}
 
 	offset := lastOffset(node.Parent())
-	isEmpty := node.ChildCount() == 0 && pc.Get(emptyListItemWithBlankLines) != nil
+	state := stateOf(pc)
+	isEmpty := node.ChildCount() == 0 && state.emptyItemBlankLine
 	indent, _ := util.IndentWidth(line, reader.LineOffset())
-	if (isEmpty || indent < offset) && indent < 4 {
-		_, typ := matchesListItem(line, true)
+	delta := b.options.columnDelta(node.Parent(), reader)
+	indent += delta
+	if (isEmpty || indent < offset) && indent < b.options.markerIndent() {
+		_, typ := b.options.matchListItemIn(node, line, true)
+		if typ == notList && node.Parent().(*ast.List).IsOrdered() {
+			_, typ = b.options.matchBareHashItemIn(node, reader, line)
+		}
 		// new list item found
 		if typ != notList {
-			pc.Set(skipListParserKey, listItemFlagValue)
+			state.skipListParser = true
 			return parser.Close
 		}
 		if !isEmpty {
 			return parser.Close
 		}
 	}
-	pos, padding := util.IndentPosition(line, reader.LineOffset(), offset)
+	pos, padding := util.IndentPosition(line, reader.LineOffset(), max(offset-delta, 0))
 	reader.AdvanceAndSetPadding(pos, padding)
 
 	return parser.Continue | parser.HasChildren
@@ -597,103 +1553,180 @@ func (b *fancyListItemParser) CanInterruptParagraph() bool {
 }
 
 func (b *fancyListItemParser) CanAcceptIndentedLine() bool {
-	return false
+	return b.options.RelaxedIndent
 }
 
 // fancyListHTMLRenderer provides HTML rendering for fancy lists.
 type fancyListHTMLRenderer struct {
 	html.Config
+	options FancyListsOptions
 }
 
 func (r *fancyListHTMLRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
 	reg.Register(ast.KindList, r.renderList)
 }
 
+// renderList writes the opening and closing list tags. Attributes are always
+// emitted in the same order: class, type, start, style, dir, the data attributes
+// written by this extension, and then any remaining user attributes sorted by
+// name.
 func (r *fancyListHTMLRenderer) renderList(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
 	n := node.(*ast.List)
+	marked := r.options.markedList(n)
 	tag := "ul"
-	if n.IsOrdered() {
+	if n.IsOrdered() && !(marked && r.options.MarkerSpans == MarkerSpansList) {
 		tag = "ol"
 	}
-	if entering {
+	// Lists rendered exactly as the core renderer would are not fancy
+	boundary := r.options.BoundaryComments && !(isPlainList(n) && r.options.corePlainList(n))
+	if entering && boundary {
+		r.writeBoundary(w, n, true)
+	}
+	r.options.writeIndent(w, n)
+	if entering && r.options.bareOutput() {
 		_ = w.WriteByte('<')
 		_, _ = w.WriteString(tag)
-
-		// Handle class attribute - combine fancy list classes with user-defined classes
-		var classValues []string
-
-		if n.IsOrdered() {
-			// Add fancy class and determine list type class
-			classValues = append(classValues, "fancy")
-
-			if typeAttr, ok := n.AttributeString("type"); ok {
-				typeBytes, ok := typeAttr.([]byte)
-				if !ok {
-					// Handle string case
-					if typeStr, ok := typeAttr.(string); ok {
-						typeBytes = []byte(typeStr)
-					}
-				}
-				if typeBytes != nil {
-					typeStr := string(typeBytes)
-					switch typeStr {
-					case "a":
-						classValues = append(classValues, "fl-lcalpha")
-					case "A":
-						classValues = append(classValues, "fl-ucalpha")
-					case "i":
-						classValues = append(classValues, "fl-lcroman")
-					case "I":
-						classValues = append(classValues, "fl-ucroman")
-					default:
-						classValues = append(classValues, "fl-num")
-					}
+		if typ := listType(n); n.IsOrdered() && typ != "1" {
+			_, _ = w.WriteString(` type="`)
+			_, _ = w.WriteString(typ)
+			_ = w.WriteByte('"')
+		}
+		if r.options.Semantic && n.IsOrdered() && !r.options.Minimal {
+			if n.Start != 1 {
+				_, _ = w.WriteString(` start="`)
+				_, _ = w.WriteString(strconv.Itoa(n.Start))
+				_ = w.WriteByte('"')
+			}
+			if isReversed(n) {
+				if r.XHTML {
+					_, _ = w.WriteString(` reversed="reversed"`)
 				} else {
-					classValues = append(classValues, "fl-num")
+					_, _ = w.WriteString(` reversed`)
 				}
-			} else {
-				classValues = append(classValues, "fl-num")
 			}
 		}
+		_ = w.WriteByte('>')
+	} else if entering && isPlainList(n) && r.options.corePlainList(n) {
+		_ = w.WriteByte('<')
+		_, _ = w.WriteString(tag)
+		r.writePlainListAttributes(w, n)
+		_ = w.WriteByte('>')
+	} else if entering {
+		_ = w.WriteByte('<')
+		_, _ = w.WriteString(tag)
 
-		// Add user-defined class attributes from goldmark-attributes extension
-		if classAttr, ok := n.AttributeString("class"); ok {
-			if classBytes, ok := classAttr.([]byte); ok {
-				classValues = append(classValues, string(classBytes))
-			} else if classStr, ok := classAttr.(string); ok {
-				classValues = append(classValues, classStr)
+		padding := listPadding(n)
+
+		// Handle class attribute - combine fancy list classes with user-defined classes
+		classAttr, hasClass := n.AttributeString("class")
+		if hasClass {
+			switch classAttr.(type) {
+			case []byte, string:
+			default:
+				hasClass = false
+			}
+		}
+		typeAttr, hasType := userType(n)
+		quiz := r.options.quizName(n)
+
+		// Resolve collisions between the computed and user-supplied class and type
+		fancyClass := n.IsOrdered()
+		var bulletClass string
+		if !n.IsOrdered() && r.options.BulletClasses {
+			bulletClass = bulletClasses[min(listDepth(n), len(bulletClasses)-1)]
+		}
+		typ := r.renderedType(n)
+		switch r.options.AttributePolicy {
+		case AttributesUserWins:
+			fancyClass = fancyClass && !hasClass
+			if hasClass {
+				bulletClass = ""
 			}
+		case AttributesExtensionWins:
+			hasClass = hasClass && !n.IsOrdered() && bulletClass == ""
 		}
 
+		// The inline style follows the classes it stands in for
+		var style string
+		if r.options.InlineStyles != InlineStylesNone {
+			hidden := marked || r.options.Checklists == ChecklistReplace && isChecklist(n)
+			style = inlineListStyle(n.IsOrdered(), typ, bulletClass, hidden)
+			if r.options.InlineStyles == InlineStylesOnly {
+				fancyClass = false
+				bulletClass = ""
+			}
+		}
+		userStyle, hasStyle := n.AttributeString("style")
+		hasStyle = hasStyle && style != "" && r.passthrough(attrNameStyle)
+
 		// Write the class attribute if we have any classes
-		if len(classValues) > 0 {
+		if fancyClass || bulletClass != "" || hasClass {
 			_, _ = w.WriteString(` class="`)
-			for i, class := range classValues {
-				if i > 0 {
+			if bulletClass != "" {
+				_, _ = w.WriteString(bulletClass)
+				if hasClass {
 					_ = w.WriteByte(' ')
 				}
-				_, _ = w.WriteString(class)
+			}
+			if fancyClass {
+				// Add fancy class and determine list type class
+				_, _ = w.WriteString(ClassFancy)
+				_ = w.WriteByte(' ')
+				_, _ = w.WriteString(r.options.classMap().Class(typ))
+				if r.options.DelimiterClasses {
+					_ = w.WriteByte(' ')
+					if n.Marker == ')' {
+						_, _ = w.WriteString(ClassParen)
+					} else {
+						_, _ = w.WriteString(ClassPeriod)
+					}
+				}
+				if marked {
+					_ = w.WriteByte(' ')
+					_, _ = w.WriteString(ClassMarked)
+				}
+				if r.options.Checklists == ChecklistReplace && isChecklist(n) {
+					_ = w.WriteByte(' ')
+					_, _ = w.WriteString(ClassChecklist)
+				}
+				if quiz != nil {
+					_ = w.WriteByte(' ')
+					_, _ = w.WriteString(ClassQuiz)
+				}
+				if padding > 0 && r.options.Padding == PaddingClass {
+					_ = w.WriteByte(' ')
+					_, _ = w.WriteString(ClassPaddingPrefix)
+					_, _ = w.WriteString(strconv.Itoa(padding))
+				}
+				if hasClass {
+					_ = w.WriteByte(' ')
+				}
+			}
+			// Add user-defined class attributes from goldmark-attributes extension
+			if hasClass {
+				writeAttributeValue(w, classAttr)
 			}
 			_ = w.WriteByte('"')
 		}
 
-		// Handle ordered list specific attributes
-		if n.IsOrdered() {
-			if typeAttr, ok := n.AttributeString("type"); ok {
+		// Bullet lists have no computed type, so a user type is always kept
+		if !n.IsOrdered() && hasType {
+			_, _ = w.WriteString(` type="`)
+			writeAttributeValue(w, typeAttr)
+			_ = w.WriteByte('"')
+		}
+
+		// Handle ordered list specific attributes; the marker spans carry
+		// the numbering of marked lists
+		if n.IsOrdered() && !marked {
+			if hasType && r.options.AttributePolicy == AttributesUserWins {
 				_, _ = w.WriteString(` type="`)
-				typeBytes, ok := typeAttr.([]byte)
-				if !ok {
-					// Handle string case
-					if typeStr, ok := typeAttr.(string); ok {
-						typeBytes = []byte(typeStr)
-					}
-				}
-				if typeBytes != nil {
-					_, _ = w.Write(typeBytes)
-				}
+				writeAttributeValue(w, typeAttr)
+				_ = w.WriteByte('"')
+			} else if typ != "1" || !r.options.OmitNumericType {
+				_, _ = w.WriteString(` type="`)
+				_, _ = w.WriteString(typ)
 				_ = w.WriteByte('"')
-			} else {
-				_, _ = w.WriteString(` type="1"`)
 			}
 
 			if n.Start != 1 {
@@ -701,44 +1734,282 @@ func (r *fancyListHTMLRenderer) renderList(w util.BufWriter, source []byte, node
 				_, _ = w.WriteString(` start="`)
 				_, _ = w.WriteString(strconv.Itoa(n.Start))
 				_ = w.WriteByte('"')
-			} else {
-				// Always add start="1" for consistency
+			} else if !r.options.Renumber {
+				// Always add start="1" for consistency, unless the source
+				// numbering is ignored altogether
 				_, _ = w.WriteString(` start="1"`)
 			}
+
+			if isReversed(n) {
+				if r.XHTML {
+					_, _ = w.WriteString(` reversed="reversed"`)
+				} else {
+					_, _ = w.WriteString(` reversed`)
+				}
+			}
 		}
 
-		// Handle all other attributes from goldmark-attributes extension
-		if n.Attributes() != nil {
-			for _, attr := range n.Attributes() {
-				name := string(attr.Name)
-				// Skip attributes we've already handled
-				if name != "class" && name != "type" {
-					_, _ = w.WriteString(` `)
-					_, _ = w.WriteString(name)
-					_, _ = w.WriteString(`="`)
-					// Handle different value types
-					if valueBytes, ok := attr.Value.([]byte); ok {
-						_, _ = w.Write(valueBytes)
-					} else if valueStr, ok := attr.Value.(string); ok {
-						_, _ = w.WriteString(valueStr)
-					}
-					_ = w.WriteByte('"')
+		if style != "" {
+			_, _ = w.WriteString(` style="`)
+			_, _ = w.WriteString(style)
+			if hasStyle {
+				_, _ = w.WriteString("; ")
+				writeAttributeValue(w, userStyle)
+			}
+			_ = w.WriteByte('"')
+		}
+
+		if n.IsOrdered() {
+			digits, _ := n.Attribute(attrNameDigits)
+			if digits != nil {
+				// Lists written with Arabic-Indic digits are right-to-left
+				// unless the user set a direction explicitly
+				if _, ok := n.AttributeString("dir"); !ok || !r.passthrough(attrNameDir) {
+					_, _ = w.WriteString(` dir="rtl"`)
 				}
 			}
+
+			if padding > 0 && r.options.Padding == PaddingDataAttribute {
+				_, _ = w.WriteString(` data-padding="`)
+				_, _ = w.WriteString(strconv.Itoa(padding))
+				_ = w.WriteByte('"')
+			}
+
+			if digits != nil {
+				_, _ = w.WriteString(` data-digits="`)
+				writeAttributeValue(w, digits)
+				_ = w.WriteByte('"')
+			}
+
+			if prefix := listPrefix(n); prefix != nil {
+				_, _ = w.WriteString(` data-prefix="`)
+				writeAttributeValue(w, prefix)
+				_ = w.WriteByte('"')
+			}
+
+			if suffix := listSuffix(n); suffix != nil {
+				_, _ = w.WriteString(` data-suffix="`)
+				writeAttributeValue(w, suffix)
+				_ = w.WriteByte('"')
+			}
 		}
 
-		_, _ = w.WriteString(">\n")
+		if r.options.microdataList(n) {
+			writeListMicrodata(w)
+		}
+
+		// Handle all other attributes from goldmark-attributes extension; the
+		// quiz attribute names the radio group instead
+		writeUserAttributes(w, n.Attributes(), func(name []byte) bool {
+			return !(quiz != nil && bytes.Equal(name, attrNameQuiz)) &&
+				!(hasStyle && bytes.Equal(name, attrNameStyle)) && r.passthrough(name)
+		})
+
+		_ = w.WriteByte('>')
 	} else {
 		_, _ = w.WriteString("</")
 		_, _ = w.WriteString(tag)
-		_, _ = w.WriteString(">\n")
+		_ = w.WriteByte('>')
+	}
+	if !r.options.Compact {
+		_ = w.WriteByte('\n')
+	}
+	if !entering && boundary {
+		r.writeBoundary(w, n, false)
 	}
 	return ast.WalkContinue, nil
 }
 
+// renderedType returns the type n is rendered with: its computed type, or
+// the user's type where the AttributePolicy lets it win. Under
+// AttributesMerge a user type replaces the computed one if HTML defines it.
+func (r *fancyListHTMLRenderer) renderedType(n *ast.List) string {
+	typ := listType(n)
+	if typeAttr, ok := userType(n); ok {
+		switch r.options.AttributePolicy {
+		case AttributesMerge:
+			if isTypeValue(typeAttr) {
+				typ = typeString(typeAttr)
+			}
+		case AttributesUserWins:
+			typ = typeString(typeAttr)
+		}
+	}
+	return typ
+}
+
+// countsFromOne reports whether list is numbered 1, 2, 3, ... as browsers
+// number an <ol> without attributes.
+func countsFromOne(list ast.Node) bool {
+	if n, ok := list.(*ast.List); !ok || n.Start != 1 || isReversed(n) {
+		return false
+	}
+	return true
+}
+
+// isChecklist reports whether every item of a list starts with a task
+// checkbox.
+func isChecklist(n ast.Node) bool {
+	if n.FirstChild() == nil {
+		return false
+	}
+	for item := n.FirstChild(); item != nil; item = item.NextSibling() {
+		block := item.FirstChild()
+		if block == nil || block.FirstChild() == nil || block.FirstChild().Kind() != east.KindTaskCheckBox {
+			return false
+		}
+	}
+	return true
+}
+
+// bulletClasses holds the bullet list classes by nesting depth. Lists nested
+// deeper than the last entry use it too, as browsers do.
+var bulletClasses = [...]string{ClassDisc, ClassCircle, ClassSquare}
+
+// listDepth returns the number of lists, ordered or not, that contain n.
+func listDepth(n ast.Node) int {
+	depth := 0
+	for p := n.Parent(); p != nil; p = p.Parent() {
+		if p.Kind() == ast.KindList {
+			depth++
+		}
+	}
+	return depth
+}
+
+// bareOutput reports whether lists are rendered without classes, data
+// attributes, user attributes, marker spans or microdata, as with
+// WithMinimalOutput and WithSemanticOutput.
+func (e *FancyListsOptions) bareOutput() bool {
+	return e.Minimal || e.Semantic
+}
+
+// corePlainList reports whether a plain list is rendered as Goldmark's core
+// renderer would: always in CommonMark mode, outside fancy regions, for the
+// lists the options leave to the core parser, and for those the core parser
+// opened while the extension was disabled.
+func (e *FancyListsOptions) corePlainList(n *ast.List) bool {
+	if !e.fancyIn(n) {
+		return true
+	}
+	if _, ok := n.Attribute(attrNameCore); ok {
+		return true
+	}
+	if n.IsOrdered() {
+		return e.CommonMark || !e.typeEnabled("1")
+	}
+	return e.CommonMark || e.BulletPassthrough
+}
+
+// isPlainList reports whether a list uses only CommonMark markers: bullets or
+// ASCII decimal numbers.
+func isPlainList(n ast.Node) bool {
+	for _, name := range [...][]byte{attrNameListType, attrNameDigits, attrNameDelimiter, attrNameReversed, attrNamePrefix, attrNameSuffix} {
+		if _, ok := n.Attribute(name); ok {
+			return false
+		}
+	}
+	return true
+}
+
+// writePlainListAttributes writes the attributes of a plain list the way
+// Goldmark's core renderer does: a start attribute only when it is not 1,
+// and no fancy classes or type.
+func (r *fancyListHTMLRenderer) writePlainListAttributes(w util.BufWriter, n *ast.List) {
+	if n.IsOrdered() && n.Start != 1 {
+		_, _ = w.WriteString(` start="`)
+		_, _ = w.WriteString(strconv.Itoa(n.Start))
+		_ = w.WriteByte('"')
+	}
+	for _, name := range [...]string{"class", "type"} {
+		if v, ok := n.AttributeString(name); ok {
+			switch v.(type) {
+			case []byte, string:
+				_ = w.WriteByte(' ')
+				_, _ = w.WriteString(name)
+				_, _ = w.WriteString(`="`)
+				writeAttributeValue(w, v)
+				_ = w.WriteByte('"')
+			}
+		}
+	}
+	writeUserAttributes(w, n.Attributes(), r.passthrough)
+}
+
+// passthrough reports whether a user attribute may be written. Everything is
+// written when html.WithUnsafe is set; otherwise the configured filter applies.
+func (r *fancyListHTMLRenderer) passthrough(name []byte) bool {
+	return r.Unsafe || r.options.attributeAllowed(name)
+}
+
+// writeUserAttributes writes the attributes not handled by the renderer itself
+// that allowed accepts, sorted by name so output is stable regardless of the
+// order they were set.
+func writeUserAttributes(w util.BufWriter, attrs []ast.Attribute, allowed func([]byte) bool) {
+	if len(attrs) == 0 {
+		return
+	}
+	sorted := make([]ast.Attribute, 0, len(attrs))
+	for _, attr := range attrs {
+		// Skip attributes we've already handled
+		name := string(attr.Name)
+		if name != "class" && name != "type" && !isInternalAttribute(attr.Name) && allowed(attr.Name) {
+			sorted = append(sorted, attr)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].Name, sorted[j].Name) < 0
+	})
+	for _, attr := range sorted {
+		_ = w.WriteByte(' ')
+		_, _ = w.Write(attr.Name)
+		_, _ = w.WriteString(`="`)
+		writeAttributeValue(w, attr.Value)
+		_ = w.WriteByte('"')
+	}
+}
+
+// isInternalAttribute reports whether name is metadata recorded by the parser
+// for the renderer, which is never written out as an HTML attribute.
+func isInternalAttribute(name []byte) bool {
+	return bytes.Equal(name, attrNamePadding) || bytes.Equal(name, attrNameDelimiter) ||
+		bytes.Equal(name, attrNameDigits) || bytes.Equal(name, attrNameListType) ||
+		bytes.Equal(name, attrNameMarker) || bytes.Equal(name, attrNameExplicit) ||
+		bytes.Equal(name, attrNameColumn) || bytes.Equal(name, attrNameReversed) ||
+		bytes.Equal(name, attrNamePrefix) || bytes.Equal(name, attrNameBullet) ||
+		bytes.Equal(name, attrNameSuffix) || bytes.Equal(name, attrNameSpacing) ||
+		bytes.Equal(name, attrNameCore)
+}
+
+// listPadding returns the zero-padded marker width recorded for a list, or 0.
+func listPadding(n ast.Node) int {
+	if v, ok := n.Attribute(attrNamePadding); ok {
+		if width, ok := v.(int); ok {
+			return width
+		}
+	}
+	return 0
+}
+
+// writeAttributeValue writes an HTML-escaped attribute value stored either as
+// []byte or as string. Values of any other type are skipped. Like Goldmark's
+// own renderer, values are escaped whether or not html.WithUnsafe is set: an
+// unescaped quote would end the attribute and let the rest of the value
+// inject markup.
+func writeAttributeValue(w util.BufWriter, value interface{}) {
+	// Handle different value types
+	switch v := value.(type) {
+	case []byte:
+		_, _ = w.Write(util.EscapeHTML(v))
+	case string:
+		_, _ = w.Write(util.EscapeHTML([]byte(v)))
+	}
+}
+
 // fancyListItemHTMLRenderer provides HTML rendering for fancy list items.
 type fancyListItemHTMLRenderer struct {
 	html.Config
+	options FancyListsOptions
 }
 
 func (r *fancyListItemHTMLRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
@@ -746,19 +2017,102 @@ func (r *fancyListItemHTMLRenderer) RegisterFuncs(reg renderer.NodeRendererFuncR
 }
 
 func (r *fancyListItemHTMLRenderer) renderListItem(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if r.options.ListItemRenderer != nil {
+		if err := r.options.ListItemRenderer(w, n.(*ast.ListItem), entering); err != nil {
+			return ast.WalkStop, err
+		}
+		return ast.WalkContinue, nil
+	}
 	if entering {
+		r.options.writeIndent(w, n)
 		_, _ = w.WriteString("<li")
-		// No value attribute - the start attribute on the parent ol handles numbering
+		// By default there is no value attribute - the start attribute on the parent ol
+		// handles numbering - but some sanitizers strip start, so it can be enabled.
+		// Items whose value was set explicitly with '#5.' always carry it.
+		// Minimal output has no start attribute, so items carry the numbering
+		// of lists that do not count up from 1.
+		if _, explicit := n.Attribute(attrNameExplicit); r.options.ItemValues || explicit || r.options.Minimal && !countsFromOne(n.Parent()) {
+			if v, ok := n.Attribute(attrNameValue); ok {
+				if value, ok := v.(int); ok {
+					_, _ = w.WriteString(` value="`)
+					_, _ = w.WriteString(strconv.Itoa(value))
+					_ = w.WriteByte('"')
+				}
+			}
+		}
+		list, _ := n.Parent().(*ast.List)
+		if list != nil && r.options.labeledList(list) {
+			r.options.writeItemLabel(w, n, list)
+		}
+		microdata := list != nil && r.options.microdataList(list)
+		if microdata {
+			writeItemMicrodata(w)
+		}
+		if r.selfClosing(n, list) {
+			_, _ = w.WriteString(" />")
+			if !r.options.Compact {
+				_ = w.WriteByte('\n')
+			}
+			return ast.WalkSkipChildren, nil
+		}
 		_ = w.WriteByte('>')
+		if microdata {
+			writeItemPosition(w, n, r.XHTML)
+		}
+		quiz := r.options.quizName(list)
+		if quiz != nil {
+			r.options.writeQuizInput(w, n, quiz, r.XHTML)
+		}
 
 		fc := n.FirstChild()
-		if fc != nil {
+		if list != nil && r.options.markedList(list) {
+			if marker := r.options.itemMarker(n, list, source); marker != nil {
+				_, _ = w.WriteString(`<span class="` + ClassMarker + `">`)
+				_, _ = w.Write(util.EscapeHTML(marker))
+				_, _ = w.WriteString("</span>")
+				if _, ok := fc.(*ast.TextBlock); ok {
+					_ = w.WriteByte(' ')
+				}
+			}
+		}
+		if fc != nil && !r.options.Compact {
 			if _, ok := fc.(*ast.TextBlock); !ok {
 				_ = w.WriteByte('\n')
 			}
 		}
+		if r.options.EmptyItems == EmptyItemsNewline && !r.options.Compact && r.emptyItem(n, list) {
+			_ = w.WriteByte('\n')
+		}
 	} else {
-		_, _ = w.WriteString("</li>\n")
+		list, _ := n.Parent().(*ast.List)
+		if r.selfClosing(n, list) {
+			return ast.WalkContinue, nil
+		}
+		if r.closesOnNewLine(n, list) {
+			r.options.writeIndent(w, n)
+		}
+		if r.options.quizName(n.Parent()) != nil {
+			_, _ = w.WriteString("</label>")
+		}
+		_, _ = w.WriteString("</li>")
+		if !r.options.Compact {
+			_ = w.WriteByte('\n')
+		}
 	}
 	return ast.WalkContinue, nil
-}
\ No newline at end of file
+}
+
+// emptyItem reports whether item renders with nothing between its tags: it
+// has no children and its list adds no marker span, quiz input or
+// microdata position.
+func (r *fancyListItemHTMLRenderer) emptyItem(item ast.Node, list *ast.List) bool {
+	if item.HasChildren() {
+		return false
+	}
+	return list == nil || !r.options.markedList(list) && r.options.quizName(list) == nil && !r.options.microdataList(list)
+}
+
+// selfClosing reports whether item is written as "<li />".
+func (r *fancyListItemHTMLRenderer) selfClosing(item ast.Node, list *ast.List) bool {
+	return r.options.EmptyItems == EmptyItemsSelfClosing && r.XHTML && r.emptyItem(item, list)
+}
@@ -1,6 +1,8 @@
 package fancylists
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/fatih/color"
@@ -8,7 +10,10 @@ import (
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
 	"github.com/yuin/goldmark/testutil"
+	"github.com/yuin/goldmark/text"
 )
 
 // Run Basic tests with no other extensions enabled
@@ -88,11 +93,198 @@ func TestFancyListsWithBlockAttributes(t *testing.T) {
 	}
 }
 
+// Run tests with the compact output option enabled
+var mdCompact = CreateGoldmarkInstance(createOptions{
+	fancyOptions: []Option{WithCompact()},
+})
+
+func TestFancyListsCompact(t *testing.T) {
+	for i, c := range casesCompact {
+		testutil.DoTestCase(mdCompact, testutil.MarkdownTestCase{
+			No:          i,
+			Description: c.desc,
+			Markdown:    c.md,
+			Expected:    c.html,
+		}, t)
+	}
+}
+
+// Run tests that each configure their own extension options
+func TestFancyListsOptions(t *testing.T) {
+	for i, c := range casesOptions {
+		md := CreateGoldmarkInstance(createOptions{
+			blockAttributes: c.blockAttributes,
+			fancyOptions:    c.options,
+		})
+		testutil.DoTestCase(md, testutil.MarkdownTestCase{
+			No:          i,
+			Description: c.desc,
+			Markdown:    c.md,
+			Expected:    c.html,
+		}, t)
+	}
+}
+
+func TestMarkerConversion(t *testing.T) {
+	romans := []struct {
+		in   string
+		want int
+		ok   bool
+	}{
+		{"i", 1, true}, {"III", 3, true}, {"iv", 4, true}, {"Ix", 9, true},
+		{"v", 0, false}, {"ivx", 0, false}, {"iz", 0, false}, {"", 0, false},
+	}
+	for _, c := range romans {
+		got, ok := romanToNumber([]byte(c.in))
+		if got != c.want || ok != c.ok {
+			t.Errorf("romanToNumber(%q) = %d, %v; want %d, %v", c.in, got, ok, c.want, c.ok)
+		}
+	}
+	alphas := []struct {
+		in   string
+		want int
+	}{
+		{"a", 1}, {"C", 3}, {"z", 26}, {"aa", 27}, {"Ab", 28}, {"a1", 0}, {"", 0},
+		{"zzzzzz", 321272406}, {"zzzzzzz", 0},
+	}
+	for _, c := range alphas {
+		if got := alphabeticToNumber([]byte(c.in)); got != c.want {
+			t.Errorf("alphabeticToNumber(%q) = %d; want %d", c.in, got, c.want)
+		}
+	}
+}
+
+func TestMayBeListItem(t *testing.T) {
+	lines := []string{
+		"a. item", "iv) item", "abcdef. item", "   B. item", "#. item", "1. item", "- item",
+		"Letters start prose", "abcdefg. too long", "ab", "a.", "",
+	}
+	for _, line := range lines {
+		_, typ := matchesListItem([]byte(line), true)
+		if typ != notList && !mayBeListItem([]byte(line)) {
+			t.Errorf("mayBeListItem(%q) rejected a list item", line)
+		}
+	}
+	if mayBeListItem([]byte("Letters start prose")) {
+		t.Errorf("mayBeListItem accepted a prose line")
+	}
+}
+
+func TestTriggers(t *testing.T) {
+	for _, b := range NewFancyLists().triggers() {
+		if b >= 0x80 {
+			t.Errorf("default triggers include the non-ASCII byte %#x", b)
+		}
+	}
+	ext := NewFancyLists(WithFullWidthMarkers(), WithArabicIndicDigits(), WithUnicodeRoman(), WithParenthesizedMarkers())
+	triggers := string(ext.triggers())
+	for _, b := range []byte{0xEF, 0xD9, 0xDB, 0xE2, 0xF0} {
+		if strings.IndexByte(triggers, b) < 0 {
+			t.Errorf("triggers lack the lead byte %#x of an enabled marker set", b)
+		}
+	}
+}
+
+func TestMayBeMarker(t *testing.T) {
+	ext := NewFancyLists(WithStepMarkers())
+	if ext.mayBeMarker([]byte("Hello world")) {
+		t.Errorf("mayBeMarker accepted a prose line with step markers enabled")
+	}
+	for _, line := range []string{"step 1. item", "Step 2. item", "a. item"} {
+		if !ext.mayBeMarker([]byte(line)) {
+			t.Errorf("mayBeMarker(%q) rejected a list item", line)
+		}
+	}
+}
+
+func TestItemValueAttribute(t *testing.T) {
+	source := []byte("c. First item\n#. Second item\n")
+	doc := mdBasic.Parser().Parse(text.NewReader(source))
+	list := doc.FirstChild()
+	want := 3
+	for item := list.FirstChild(); item != nil; item = item.NextSibling() {
+		v, ok := item.AttributeString("value")
+		if !ok || v != want {
+			t.Errorf("item value = %v (%T), want %d", v, v, want)
+		}
+		want++
+	}
+	if typ, _ := list.AttributeString("type"); string(typ.([]byte)) != "a" {
+		t.Errorf("list type = %q, want %q", typ, "a")
+	}
+}
+
+func TestAttributeEscaping(t *testing.T) {
+	cases := []struct {
+		desc string
+		md   string
+		html string
+	}{
+		{
+			desc: "quote in a value",
+			md:   "1. One\n{data-note=\"say \\\"hi\\\"\"}\n",
+			html: `<ol class="fancy fl-num" type="1" start="1" data-note="say &quot;hi&quot;">`,
+		},
+		{
+			desc: "markup in a value",
+			md:   "a. One\n{title=\"<script>alert(1)</script>\"}\n",
+			html: `<ol class="fancy fl-lcalpha" type="a" start="1" title="&lt;script&gt;alert(1)&lt;/script&gt;">`,
+		},
+		{
+			desc: "ampersand in a value",
+			md:   "i. One\n{title=\"Q&A\"}\n",
+			html: `<ol class="fancy fl-lcroman" type="i" start="1" title="Q&amp;A">`,
+		},
+		{
+			desc: "quote in a class",
+			md:   "- One\n{class=\"x\\\" onclick=\\\"y\"}\n",
+			html: `<ul class="x&quot; onclick=&quot;y">`,
+		},
+	}
+	for _, unsafe := range []bool{false, true} {
+		var rendererOptions []renderer.Option
+		if unsafe {
+			rendererOptions = append(rendererOptions, html.WithUnsafe())
+		}
+		md := goldmark.New(
+			goldmark.WithExtensions(FancyLists),
+			blockattr.Enable,
+			goldmark.WithRendererOptions(rendererOptions...),
+		)
+		for _, c := range cases {
+			var buf bytes.Buffer
+			if err := md.Convert([]byte(c.md), &buf); err != nil {
+				t.Fatal(err)
+			}
+			if got, _, _ := strings.Cut(buf.String(), "\n"); got != c.html {
+				t.Errorf("%s (unsafe=%v):\ngot  %s\nwant %s", c.desc, unsafe, got, c.html)
+			}
+		}
+	}
+}
+
+func TestAttributeFilterUnsafe(t *testing.T) {
+	md := goldmark.New(
+		goldmark.WithExtensions(NewFancyLists(WithAttributeFilter(nil, DefaultDeniedAttributes))),
+		blockattr.Enable,
+		goldmark.WithRendererOptions(html.WithUnsafe()),
+	)
+	var buf bytes.Buffer
+	if err := md.Convert([]byte("1. One\n{onclick=\"x()\"}\n"), &buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `<ol class="fancy fl-num" type="1" start="1" onclick="x()">`
+	if got, _, _ := strings.Cut(buf.String(), "\n"); got != want {
+		t.Errorf("unsafe rendering filtered attributes:\ngot  %s\nwant %s", got, want)
+	}
+}
+
 // Options structure for creating Goldmark instances
 type createOptions struct {
 	blockAttributes bool
 	enableGFM       bool
 	withOptions     bool
+	fancyOptions    []Option
 }
 
 // CreateGoldmarkInstance creates and configures a new Goldmark instance.
@@ -104,7 +296,13 @@ func CreateGoldmarkInstance(opt createOptions) goldmark.Markdown {
         goldmark.WithExtensions(),
     }
 
-	if opt.withOptions {
+	if opt.fancyOptions != nil {
+		options = append(options,
+			goldmark.WithExtensions(
+				NewFancyLists(opt.fancyOptions...),
+			),
+		)
+	} else if opt.withOptions {
 		options = append(options,
 			goldmark.WithExtensions(
 				&FancyListsOptions{},
@@ -152,6 +350,22 @@ type TestCase struct {
 	html string
 }
 
+// Create structure for test cases that need specific extension options
+type OptionsTestCase struct {
+	desc            string
+	options         []Option
+	blockAttributes bool
+	md              string
+	html            string
+}
+
+// microdataItem is the HTML of a list item at position written with
+// WithMicrodata.
+func microdataItem(position, text string) string {
+	return `<li itemprop="itemListElement" itemscope itemtype="https://schema.org/ListItem">` +
+		`<meta itemprop="position" content="` + position + `">` + text + "</li>\n"
+}
+
 // Basic Test Cases
 var casesBasic = [...]TestCase{
 	{
@@ -223,6 +437,16 @@ var casesGeneral = [...]TestCase{
 2.two`,
 		html: `<p>-one</p>
 <p>2.two</p>`},
+	{
+		desc: "Start values beyond the nine-digit limit are not lists",
+		md: `1234567890. ten digits
+
+zzzzzz. largest alphabetic start
+`,
+		html: `<p>1234567890. ten digits</p>
+<ol class="fancy fl-lcalpha" type="a" start="321272406">
+<li>largest alphabetic start</li>
+</ol>`},
 	{
 		desc: "Simple Unordered List with '-'",
 		md:   `- First item
@@ -1191,6 +1415,2750 @@ var casesBlockAttributes = [...]TestCase{
 <li>Third item</li>
 </ol>
 `},
+	{
+		desc: `ATTR: Ordered List with user attributes emitted in sorted order after class, type and start`,
+		md:   `a. First item
+b. Second item
+{.foo zeta="1" alpha="2" mid="3"}
+`,
+		html: `<ol class="fancy fl-lcalpha foo" type="a" start="1" alpha="2" mid="3" zeta="1">
+<li>First item</li>
+<li>Second item</li>
+</ol>`},
+	{
+		desc: `ATTR: Unordered List with user attributes emitted in sorted order after class`,
+		md:   `- First item
+- Second item
+{zeta="1" .foo alpha="2"}
+`,
+		html: `<ul class="foo" alpha="2" zeta="1">
+<li>First item</li>
+<li>Second item</li>
+</ul>`},
+}
+
+// Compact output Test Cases
+var casesCompact = [...]TestCase{
+	{
+		desc: "COMPACT: Simple Ordered List",
+		md: `a. First item
+b. Second item
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1"><li>First item</li><li>Second item</li></ol>`,
+	},
+	{
+		desc: "COMPACT: Nested lists",
+		md: `1. First item
+   - Sub one
+   - Sub two
+2. Second item
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1"><li>First item
+<ul><li>Sub one</li><li>Sub two</li></ul></li><li>Second item</li></ol>`,
+	},
 }
 
+// Test Cases run with per-case extension options
+var casesOptions = [...]OptionsTestCase{
+	{
+		desc:    "MAXSTART: alphabetic start above the cap is rejected",
+		options: []Option{WithMaxStart(100, StartLimitReject)},
+		md: `vi. is not a list here
+
+c. is still a list
+`,
+		html: `<p>vi. is not a list here</p>
+<ol class="fancy fl-lcalpha" type="a" start="3">
+<li>is still a list</li>
+</ol>`,
+	},
+	{
+		desc:    "MAXSTART: numeric start above the cap is clamped even when rejecting",
+		options: []Option{WithMaxStart(100, StartLimitReject)},
+		md: `500. First
+501. Second
+`,
+		html: `<ol class="fancy fl-num" type="1" start="100">
+<li>First</li>
+<li>Second</li>
+</ol>`,
+	},
+	{
+		desc:    "MAXSTART: full-width and prefixed numeric starts above the cap are rejected",
+		options: []Option{WithMaxStart(100, StartLimitReject), WithFullWidthMarkers(), WithStepMarkers()},
+		md: `５００. is not a list here
+
+Step 500. is not one either
+`,
+		html: `<p>５００. is not a list here</p>
+<p>Step 500. is not one either</p>`,
+	},
+	{
+		desc:    "MAXSTART: value hints above the cap are rejected",
+		options: []Option{WithMaxStart(100, StartLimitReject)},
+		md: `a. One
+#5000. Far
+#MM. Farther
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>One
+#5000. Far
+#MM. Farther</li>
+</ol>`,
+	},
+	{
+		desc:    "MAXSTART: value hints above the cap are clamped",
+		options: []Option{WithMaxStart(100, StartLimitClamp)},
+		md: `1. One
+#5000. Far
+#. Next
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1">
+<li>One</li>
+<li value="100">Far</li>
+<li>Next</li>
+</ol>`,
+	},
+	{
+		desc:    "MAXSTART: alphabetic start above the cap is clamped",
+		options: []Option{WithMaxStart(10, StartLimitClamp)},
+		md: `vi. clamped
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="10">
+<li>clamped</li>
+</ol>`,
+	},
+	{
+		desc:    "PADDING: zero-padded markers are not exposed by default",
+		options: []Option{},
+		md: `003. Third
+004. Fourth
+`,
+		html: `<ol class="fancy fl-num" type="1" start="3">
+<li>Third</li>
+<li>Fourth</li>
+</ol>`,
+	},
+	{
+		desc:    "PADDING: zero-padded markers as class",
+		options: []Option{WithPadding(PaddingClass)},
+		md: `003. Third
+004. Fourth
+`,
+		html: `<ol class="fancy fl-num fl-pad-3" type="1" start="3">
+<li>Third</li>
+<li>Fourth</li>
+</ol>`,
+	},
+	{
+		desc:            "PADDING: zero-padded markers as data attribute before user attributes",
+		options:         []Option{WithPadding(PaddingDataAttribute)},
+		blockAttributes: true,
+		md: `01. First
+02. Second
+{.foo bar="baz"}
+`,
+		html: `<ol class="fancy fl-num foo" type="1" start="1" data-padding="2" bar="baz">
+<li>First</li>
+<li>Second</li>
+</ol>`,
+	},
+	{
+		desc:    "PADDING: unpadded markers get no padding class",
+		options: []Option{WithPadding(PaddingClass)},
+		md: `10. Tenth
+`,
+		html: `<ol class="fancy fl-num" type="1" start="10">
+<li>Tenth</li>
+</ol>`,
+	},
+	{
+		desc:    "ITEMVALUES: ordered items carry their computed value",
+		options: []Option{WithItemValues()},
+		md: `c. Third
+#. Fourth
+   - bullet
+e. Fifth
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="3">
+<li value="3">Third</li>
+<li value="4">Fourth
+<ul>
+<li>bullet</li>
+</ul>
+</li>
+<li value="5">Fifth</li>
+</ol>`,
+	},
+	{
+		desc:    "AMBIGUOUS: alphabetic preference reads standalone i as a letter",
+		options: []Option{WithAmbiguousMarkers(AmbiguousAlphabetic)},
+		md: `i. Ninth letter
+j. Tenth letter
+
+Paragraph
+
+h. Eighth
+i. Ninth
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="9">
+<li>Ninth letter</li>
+<li>Tenth letter</li>
+</ol>
+<p>Paragraph</p>
+<ol class="fancy fl-lcalpha" type="a" start="8">
+<li>Eighth</li>
+<li>Ninth</li>
+</ol>`,
+	},
+	{
+		desc:    "AMBIGUOUS: alphabetic preference keeps I in a numeric list as a new alphabetic list",
+		options: []Option{WithAmbiguousMarkers(AmbiguousAlphabetic)},
+		md: `1. One
+I. Letter I
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1">
+<li>One</li>
+</ol>
+<ol class="fancy fl-ucalpha" type="A" start="9">
+<li>Letter I</li>
+</ol>`,
+	},
+	{
+		desc:    "AMBIGUOUS: roman preference reads standalone v and x as numerals",
+		options: []Option{WithAmbiguousMarkers(AmbiguousRoman)},
+		md: `v. Five
+#. Six
+
+X. Ten
+`,
+		html: `<ol class="fancy fl-lcroman" type="i" start="5">
+<li>Five</li>
+<li>Six</li>
+</ol>
+<ol class="fancy fl-ucroman" type="I" start="10">
+<li>Ten</li>
+</ol>`,
+	},
+	{
+		desc:    "AMBIGUOUS: roman preference splits a same-case alphabetic list at i",
+		options: []Option{WithAmbiguousMarkers(AmbiguousRoman)},
+		md: `h. Eighth
+i. Roman one
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="8">
+<li>Eighth</li>
+</ol>
+<ol class="fancy fl-lcroman" type="i" start="1">
+<li>Roman one</li>
+</ol>`,
+	},
+	{
+		desc:    "MIXEDCASE: mixed-case markers are normalized to the first letter by default",
+		options: []Option{},
+		md: `Ii. Two
+#. Three
+`,
+		html: `<ol class="fancy fl-ucroman" type="I" start="2">
+<li>Two</li>
+<li>Three</li>
+</ol>`,
+	},
+	{
+		desc:    "MIXEDCASE: mixed-case markers are rejected",
+		options: []Option{WithMixedCase(MixedCaseReject)},
+		md: `Ii. Not a list
+
+a. First
+bC. Not an item
+`,
+		html: `<p>Ii. Not a list</p>
+<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>First
+bC. Not an item</li>
+</ol>`,
+	},
+	{
+		desc:    "FULLWIDTH: full-width markers are plain text by default",
+		options: []Option{},
+		md: `１） First
+`,
+		html: `<p>１） First</p>`,
+	},
+	{
+		desc:    "FULLWIDTH: full-width digits with full-width parenthesis",
+		options: []Option{WithFullWidthMarkers()},
+		md: `１） First
+２） Second
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1">
+<li>First</li>
+<li>Second</li>
+</ol>`,
+	},
+	{
+		desc:    "FULLWIDTH: enclosed full-width letters and roman numerals",
+		options: []Option{WithFullWidthMarkers()},
+		md: `（ｂ） Second
+（c） Third
+
+Paragraph
+
+（ｉｉ） Two
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="2">
+<li>Second</li>
+<li>Third</li>
+</ol>
+<p>Paragraph</p>
+<ol class="fancy fl-lcroman" type="i" start="2">
+<li>Two</li>
+</ol>`,
+	},
+	{
+		desc:    "FULLWIDTH: full-width delimiters continue lists with the matching ASCII delimiter",
+		options: []Option{WithFullWidthMarkers()},
+		md: `1) ASCII
+２） Full-width
+＃） Hash
+3. New list
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1">
+<li>ASCII</li>
+<li>Full-width</li>
+<li>Hash</li>
+</ol>
+<ol class="fancy fl-num" type="1" start="3">
+<li>New list</li>
+</ol>`,
+	},
+	{
+		desc:    "FULLWIDTH: nested content is indented by characters",
+		options: []Option{WithFullWidthMarkers()},
+		md: `Ａ． Outer
+    - Inner
+`,
+		html: `<ol class="fancy fl-ucalpha" type="A" start="1">
+<li>Outer
+<ul>
+<li>Inner</li>
+</ul>
+</li>
+</ol>`,
+	},
+	{
+		desc:    "ARABIC: Arabic-Indic markers are plain text by default",
+		options: []Option{},
+		md: `١. First
+`,
+		html: `<p>١. First</p>`,
+	},
+	{
+		desc:    "ARABIC: Arabic-Indic digits are right-to-left",
+		options: []Option{WithArabicIndicDigits()},
+		md: `١. الأول
+٢. الثاني
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1" dir="rtl" data-digits="arabic-indic">
+<li>الأول</li>
+<li>الثاني</li>
+</ol>`,
+	},
+	{
+		desc:    "ARABIC: multi-digit start with extended Arabic-Indic digits",
+		options: []Option{WithArabicIndicDigits()},
+		md: `۱۲) دوازده
+۱۳) سیزده
+`,
+		html: `<ol class="fancy fl-num" type="1" start="12" dir="rtl" data-digits="persian">
+<li>دوازده</li>
+<li>سیزده</li>
+</ol>`,
+	},
+	{
+		desc:    "ARABIC: digit scripts cannot be mixed within a marker",
+		options: []Option{WithArabicIndicDigits()},
+		md: `١۲. Mixed
+`,
+		html: `<p>١۲. Mixed</p>`,
+	},
+	{
+		desc:            "ARABIC: an explicit dir attribute takes precedence",
+		options:         []Option{WithArabicIndicDigits()},
+		blockAttributes: true,
+		md: `٣. Three
+{dir="auto"}
+`,
+		html: `<ol class="fancy fl-num" type="1" start="3" data-digits="arabic-indic" dir="auto">
+<li>Three</li>
+</ol>`,
+	},
+	{
+		desc:    "ALPHABET: letters after a skipped letter are renumbered",
+		options: []Option{WithAlphabet("abcdefghjklmnpqrstuvwxyz")},
+		md: `J. Nine
+K. Ten
+
+Paragraph
+
+p. Fourteen
+`,
+		html: `<ol class="fancy fl-ucalpha" type="A" start="9">
+<li>Nine</li>
+<li>Ten</li>
+</ol>
+<p>Paragraph</p>
+<ol class="fancy fl-lcalpha" type="a" start="14">
+<li>Fourteen</li>
+</ol>`,
+	},
+	{
+		desc:    "ALPHABET: custom order with multi-letter markers",
+		options: []Option{WithAlphabet("xyz")},
+		md: `z. Three
+xx. Four
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="3">
+<li>Three</li>
+<li>Four</li>
+</ol>`,
+	},
+	{
+		desc:    "ALPHABET: letters outside the alphabet are not markers",
+		options: []Option{WithAlphabet("abc")},
+		md: `q. Not a list item
+`,
+		html: `<p>q. Not a list item</p>`,
+	},
+	{
+		desc:    "ALPHABET: roman numerals are recognized regardless of the alphabet",
+		options: []Option{WithAlphabet("abc")},
+		md: `ii. Two
+iii. Three
+`,
+		html: `<ol class="fancy fl-lcroman" type="i" start="2">
+<li>Two</li>
+<li>Three</li>
+</ol>`,
+	},
+	{
+		desc:    "BLOCKATTR: attribute lines are text by default",
+		options: []Option{},
+		md: `a. First
+{.steps}
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>First
+{.steps}</li>
+</ol>`,
+	},
+	{
+		desc:    "BLOCKATTR: built-in attributes are applied to the list above",
+		options: []Option{WithBlockAttributes()},
+		md: `a. First
+b. Second
+{.steps #intro data-level="2"}
 
+After
+`,
+		html: `<ol class="fancy fl-lcalpha steps" type="a" start="1" data-level="2" id="intro">
+<li>First</li>
+<li>Second</li>
+</ol>
+<p>After</p>`,
+	},
+	{
+		desc:    "BLOCKATTR: built-in attributes on a bullet list",
+		options: []Option{WithBlockAttributes()},
+		md: `- One
+- Two
+{.plain}
+`,
+		html: `<ul class="plain">
+<li>One</li>
+<li>Two</li>
+</ul>`,
+	},
+	{
+		desc:    "BLOCKATTR: attribute lines after a blank line or a paragraph are text",
+		options: []Option{WithBlockAttributes()},
+		md: `1. One
+
+{.steps}
+
+Paragraph
+{.note}
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1">
+<li>One</li>
+</ol>
+<p>{.steps}</p>
+<p>Paragraph
+{.note}</p>`,
+	},
+	{
+		desc:    "BLOCKATTR: trailing text after the attributes keeps the line as text",
+		options: []Option{WithBlockAttributes()},
+		md: `1. One
+{.steps} more
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1">
+<li>One
+{.steps} more</li>
+</ol>`,
+	},
+	{
+		desc:            "BLOCKATTR: built-in attributes work alongside goldmark-attributes",
+		options:         []Option{WithBlockAttributes()},
+		blockAttributes: true,
+		md: `i. One
+ii. Two
+{.steps}
+
+Paragraph
+{.note}
+`,
+		html: `<ol class="fancy fl-lcroman steps" type="i" start="1">
+<li>One</li>
+<li>Two</li>
+</ol>
+<p class="note">Paragraph</p>`,
+	},
+	{
+		desc:            "ATTRPOLICY: merge honors the user type and appends user classes",
+		options:         []Option{},
+		blockAttributes: true,
+		md: `a. One
+1. Two
+{.steps type="I"}
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>One</li>
+</ol>
+<ol class="fancy fl-ucroman steps" type="I" start="1">
+<li>Two</li>
+</ol>`,
+	},
+	{
+		desc:            "ATTRPOLICY: merge ignores a user type HTML does not define",
+		options:         []Option{},
+		blockAttributes: true,
+		md: `a. One
+{type="disc"}
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>One</li>
+</ol>`,
+	},
+	{
+		desc:            "ATTRPOLICY: user wins replaces the computed class and type",
+		options:         []Option{WithAttributePolicy(AttributesUserWins)},
+		blockAttributes: true,
+		md: `a. One
+{.steps type="I"}
+
+b. Two
+{type="I"}
+`,
+		html: `<ol class="steps" type="I" start="1">
+<li>One</li>
+</ol>
+<ol class="fancy fl-ucroman" type="I" start="2">
+<li>Two</li>
+</ol>`,
+	},
+	{
+		desc:    "ATTRPOLICY: extension wins ignores the user class and type",
+		options: []Option{WithAttributePolicy(AttributesExtensionWins), WithBlockAttributes()},
+		md: `a. One
+{.steps type="I" id="first"}
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1" id="first">
+<li>One</li>
+</ol>`,
+	},
+	{
+		desc:            "ATTRPOLICY: a user type on a bullet list is always written",
+		options:         []Option{WithAttributePolicy(AttributesExtensionWins)},
+		blockAttributes: true,
+		md: `- One
+{.plain type="square"}
+`,
+		html: `<ul class="plain" type="square">
+<li>One</li>
+</ul>`,
+	},
+	{
+		desc:            "ATTRFILTER: all attributes pass through by default",
+		options:         []Option{},
+		blockAttributes: true,
+		md: `a. One
+{onclick="x()" style="color: red" id="steps"}
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1" id="steps" onclick="x()" style="color: red">
+<li>One</li>
+</ol>`,
+	},
+	{
+		desc:            "ATTRFILTER: denied attributes are dropped",
+		options:         []Option{WithAttributeFilter(nil, DefaultDeniedAttributes)},
+		blockAttributes: true,
+		md: `a. One
+{.steps onClick="x()" onmouseover="y()" style="color: red" id="steps"}
+`,
+		html: `<ol class="fancy fl-lcalpha steps" type="a" start="1" id="steps">
+<li>One</li>
+</ol>`,
+	},
+	{
+		desc:    "ATTRFILTER: only allowed attributes are written",
+		options: []Option{WithAttributeFilter([]string{"id", "data-*"}, nil), WithBlockAttributes()},
+		md: `1. One
+{id="steps" data-level="2" title="Steps" dir="ltr"}
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1" data-level="2" id="steps">
+<li>One</li>
+</ol>`,
+	},
+	{
+		desc:    "ATTRFILTER: a filtered dir keeps the default direction",
+		options: []Option{WithArabicIndicDigits(), WithAttributeFilter(nil, []string{"dir"}), WithBlockAttributes()},
+		md: `١. One
+{dir="ltr"}
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1" dir="rtl" data-digits="arabic-indic">
+<li>One</li>
+</ol>`,
+	},
+	{
+		desc:    "COMMONMARK: plain lists render like the core list renderer",
+		options: []Option{WithCommonMarkOutput()},
+		md: `1. One
+2. Two
+
+- Bullet
+
+3) Three
+`,
+		html: `<ol>
+<li>One</li>
+<li>Two</li>
+</ol>
+<ul>
+<li>Bullet</li>
+</ul>
+<ol start="3">
+<li>Three</li>
+</ol>`,
+	},
+	{
+		desc:    "COMMONMARK: fancy lists keep their classes and type",
+		options: []Option{WithCommonMarkOutput()},
+		md: `1. One
+
+b. Two
+`,
+		html: `<ol>
+<li>One</li>
+</ol>
+<ol class="fancy fl-lcalpha" type="a" start="2">
+<li>Two</li>
+</ol>`,
+	},
+	{
+		desc:    "PRESET: Pandoc compatibility rejects mixed-case markers",
+		options: []Option{PandocCompat()},
+		md: `Ii. Not a list
+`,
+		html: `<p>Ii. Not a list</p>`,
+	},
+	{
+		desc:    "PRESET: CommonMark strict renders plain lists like the core renderer",
+		options: []Option{CommonMarkStrict()},
+		md: `2. Two
+
+c. Three
+
+１） Full-width
+`,
+		html: `<ol start="2">
+<li>Two</li>
+</ol>
+<ol class="fancy fl-lcalpha" type="a" start="3">
+<li>Three</li>
+</ol>
+<p>１） Full-width</p>`,
+	},
+	{
+		desc:    "PRESET: HTML5 semantic writes item values and padding data",
+		options: []Option{HTML5Semantic()},
+		md: `01. One
+02. Two
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1" data-padding="2">
+<li value="1">One</li>
+<li value="2">Two</li>
+</ol>`,
+	},
+	{
+		desc:    "PRESET: later options override a preset",
+		options: []Option{HTML5Semantic(), WithPadding(PaddingNone)},
+		md: `01. One
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1">
+<li value="1">One</li>
+</ol>`,
+	},
+	{
+		desc:    "BAREHASH: a bare '#' is a heading by default",
+		options: []Option{},
+		md: `a. One
+# Two
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>One</li>
+</ol>
+<h1>Two</h1>`,
+	},
+	{
+		desc:    "BAREHASH: a bare '#' continues an open ordered list",
+		options: []Option{WithBareHashMarkers()},
+		md: `b) One
+# Two
+#) Three
+# Four
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="2">
+<li>One</li>
+<li>Two</li>
+<li>Three</li>
+<li>Four</li>
+</ol>`,
+	},
+	{
+		desc:    "BAREHASH: a bare '#' does not start a list or continue a bullet list",
+		options: []Option{WithBareHashMarkers()},
+		md: `# Title
+
+- One
+# Heading
+`,
+		html: `<h1>Title</h1>
+<ul>
+<li>One</li>
+</ul>
+<h1>Heading</h1>`,
+	},
+	{
+		desc:    "BAREHASH: a bare '#' after a blank line is a heading",
+		options: []Option{WithBareHashMarkers()},
+		md: `1. a
+
+# heading
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1">
+<li>a</li>
+</ol>
+<h1>heading</h1>`,
+	},
+	{
+		desc:    "BAREHASH: an empty bare '#' is not an item",
+		options: []Option{WithBareHashMarkers()},
+		md: `1. One
+#
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1">
+<li>One</li>
+</ol>
+<h1></h1>`,
+	},
+	{
+		desc:    "HASHHINT: a numeric hint jumps the numbering",
+		options: []Option{},
+		md: `a. One
+#5. Five
+#. Six
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>One</li>
+<li value="5">Five</li>
+<li>Six</li>
+</ol>`,
+	},
+	{
+		desc:    "HASHHINT: letter hints are read in the list's type",
+		options: []Option{},
+		md: `i) One
+#x) Ten
+#) Eleven
+
+Paragraph
+
+A. One
+#C. Three
+`,
+		html: `<ol class="fancy fl-lcroman" type="i" start="1">
+<li>One</li>
+<li value="10">Ten</li>
+<li>Eleven</li>
+</ol>
+<p>Paragraph</p>
+<ol class="fancy fl-ucalpha" type="A" start="1">
+<li>One</li>
+<li value="3">Three</li>
+</ol>`,
+	},
+	{
+		desc:    "HASHHINT: a hint matching the sequence adds no value",
+		options: []Option{},
+		md: `1. One
+#2. Two
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1">
+<li>One</li>
+<li>Two</li>
+</ol>`,
+	},
+	{
+		desc:    "HASHHINT: a hinted marker can open a list",
+		options: []Option{WithItemValues()},
+		md: `#c. Three
+#. Four
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="3">
+<li value="3">Three</li>
+<li value="4">Four</li>
+</ol>`,
+	},
+	{
+		desc:    "CHECKLIST: task items are plain text by default",
+		options: []Option{},
+		md: `1. [ ] Step
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1">
+<li>[ ] Step</li>
+</ol>`,
+	},
+	{
+		desc:    "CHECKLIST: checkboxes accompany the numbers",
+		options: []Option{WithChecklists(ChecklistAccompany)},
+		md: `a. [ ] Stop the service
+b. [x] Back up the data
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1">
+<li><input disabled="" type="checkbox"> Stop the service</li>
+<li><input checked="" disabled="" type="checkbox"> Back up the data</li>
+</ol>`,
+	},
+	{
+		desc:    "CHECKLIST: checkboxes replace the numbers",
+		options: []Option{WithChecklists(ChecklistReplace)},
+		md: `1. [ ] Stop the service
+2. [X] Back up the data
+
+Paragraph
+
+1. [ ] Mixed
+2. Plain step
+`,
+		html: `<ol class="fancy fl-num fl-checklist" type="1" start="1">
+<li><input disabled="" type="checkbox"> Stop the service</li>
+<li><input checked="" disabled="" type="checkbox"> Back up the data</li>
+</ol>
+<p>Paragraph</p>
+<ol class="fancy fl-num" type="1" start="1">
+<li><input disabled="" type="checkbox"> Mixed</li>
+<li>Plain step</li>
+</ol>`,
+	},
+	{
+		desc:    "NESTEDHASH: a nested '#' list inherits the type of the previous list at its depth",
+		options: []Option{},
+		md: `1. One
+   a. Sub one
+   b. Sub two
+2. Two
+   #. Sub three
+3. Three
+   #. Sub four
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1">
+<li>One
+<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>Sub one</li>
+<li>Sub two</li>
+</ol>
+</li>
+<li>Two
+<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>Sub three</li>
+</ol>
+</li>
+<li>Three
+<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>Sub four</li>
+</ol>
+</li>
+</ol>`,
+	},
+	{
+		desc:    "NESTEDHASH: without an earlier nested list '#' is numeric",
+		options: []Option{},
+		md: `a. One
+   - Bullet
+b. Two
+   #. Sub
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>One
+<ul>
+<li>Bullet</li>
+</ul>
+</li>
+<li>Two
+<ol class="fancy fl-num" type="1" start="1">
+<li>Sub</li>
+</ol>
+</li>
+</ol>`,
+	},
+	{
+		desc:    "OFFSETS: strict offsets follow CommonMark inside block quotes",
+		options: []Option{},
+		md: `>a. One
+>   #. Two
+>   c. Three
+`,
+		html: `<blockquote>
+<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>One</li>
+<li>Two</li>
+<li>Three</li>
+</ol>
+</blockquote>`,
+	},
+	{
+		desc:    "OFFSETS: lenient offsets measure source columns inside block quotes",
+		options: []Option{WithOffsets(OffsetsLenient)},
+		md: `>a. One
+>   i. Nested
+>   #. Nested
+>b. Two
+`,
+		html: `<blockquote>
+<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>One
+<ol class="fancy fl-lcroman" type="i" start="1">
+<li>Nested</li>
+<li>Nested</li>
+</ol>
+</li>
+<li>Two</li>
+</ol>
+</blockquote>`,
+	},
+	{
+		desc:    "OFFSETS: lenient offsets keep consistent block quotes unchanged",
+		options: []Option{WithOffsets(OffsetsLenient)},
+		md: `> a. One
+>    i. Nested
+>
+>    Paragraph
+> #. Two
+`,
+		html: `<blockquote>
+<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>
+<p>One</p>
+<ol class="fancy fl-lcroman" type="i" start="1">
+<li>Nested</li>
+</ol>
+<p>Paragraph</p>
+</li>
+<li>
+<p>Two</p>
+</li>
+</ol>
+</blockquote>`,
+	},
+	{
+		desc:    "RELAXED: markers indented four to seven spaces start a list",
+		options: []Option{WithRelaxedIndent()},
+		md: `    a. One
+    b. Two
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>One</li>
+<li>Two</li>
+</ol>`,
+	},
+	{
+		desc:    "RELAXED: deeply indented markers nest under the item above",
+		options: []Option{WithRelaxedIndent()},
+		md: `a. One
+        i. Nested
+        ii. Nested
+b. Two
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>One
+<ol class="fancy fl-lcroman" type="i" start="1">
+<li>Nested</li>
+<li>Nested</li>
+</ol>
+</li>
+<li>Two</li>
+</ol>`,
+	},
+	{
+		desc:    "RELAXED: a marker indented less than the item content is a sibling",
+		options: []Option{WithRelaxedIndent()},
+		md: `iii. One
+    iv. Two
+`,
+		html: `<ol class="fancy fl-lcroman" type="i" start="3">
+<li>One</li>
+<li>Two</li>
+</ol>`,
+	},
+	{
+		desc: "RELAXED: without the option deeply indented markers are code",
+		md: `a. One
+        i. Nested
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>One
+i. Nested</li>
+</ol>`,
+	},
+	{
+		desc:    "RELAXED: eight spaces still start indented code",
+		options: []Option{WithRelaxedIndent()},
+		md: `        a. Code
+`,
+		html: `<pre><code>    a. Code
+</code></pre>`,
+	},
+	{
+		desc:    "TYPES: disabled alphabetic markers stay text",
+		options: []Option{WithTypes(Numeric, LowerRoman)},
+		md: `a. One
+b. Two
+`,
+		html: `<p>a. One
+b. Two</p>`,
+	},
+	{
+		desc:    "TYPES: disabled alphabetic markers do not interrupt an item",
+		options: []Option{WithTypes(Numeric, LowerRoman)},
+		md: `1. Written by
+A. Smith
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1">
+<li>Written by
+A. Smith</li>
+</ol>`,
+	},
+	{
+		desc:    "TYPES: enabled types keep working",
+		options: []Option{WithTypes(Numeric, LowerRoman)},
+		md: `1. One
+   i. Nested
+   ii. Nested
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1">
+<li>One
+<ol class="fancy fl-lcroman" type="i" start="1">
+<li>Nested</li>
+<li>Nested</li>
+</ol>
+</li>
+</ol>`,
+	},
+	{
+		desc:    "TYPES: ambiguous letters take the enabled roman reading",
+		options: []Option{WithTypes(LowerRoman)},
+		md: `v. Five
+vi. Six
+`,
+		html: `<ol class="fancy fl-lcroman" type="i" start="5">
+<li>Five</li>
+<li>Six</li>
+</ol>`,
+	},
+	{
+		desc:    "TYPES: ambiguous letters take the enabled alphabetic reading",
+		options: []Option{WithTypes(LowerAlpha)},
+		md: `h. Eight
+i. Nine
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="8">
+<li>Eight</li>
+<li>Nine</li>
+</ol>`,
+	},
+	{
+		desc:    "TYPES: without Numeric decimal lists render as plain CommonMark",
+		options: []Option{WithTypes(LowerAlpha)},
+		md: `3. Three
+4. Four
+`,
+		html: `<ol start="3">
+<li>Three</li>
+<li>Four</li>
+</ol>`,
+	},
+	{
+		desc:    "REVERSED: descending numeric markers",
+		options: []Option{WithReversedLists()},
+		md: `3. Three
+2. Two
+1. One
+`,
+		html: `<ol class="fancy fl-num" type="1" start="3" reversed>
+<li>Three</li>
+<li>Two</li>
+<li>One</li>
+</ol>`,
+	},
+	{
+		desc:    "REVERSED: descending roman markers",
+		options: []Option{WithReversedLists()},
+		md: `iii. Three
+ii. Two
+i. One
+`,
+		html: `<ol class="fancy fl-lcroman" type="i" start="3" reversed>
+<li>Three</li>
+<li>Two</li>
+<li>One</li>
+</ol>`,
+	},
+	{
+		desc:    "REVERSED: descending alphabetic markers with item values",
+		options: []Option{WithReversedLists(), WithItemValues()},
+		md: `C) Three
+B) Two
+A) One
+`,
+		html: `<ol class="fancy fl-ucalpha" type="A" start="3" reversed>
+<li value="3">Three</li>
+<li value="2">Two</li>
+<li value="1">One</li>
+</ol>`,
+	},
+	{
+		desc:    "REVERSED: a marker out of sequence keeps the list ascending",
+		options: []Option{WithReversedLists()},
+		md: `c. Three
+b. Two
+b. Two again
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="3">
+<li>Three</li>
+<li>Two</li>
+<li>Two again</li>
+</ol>`,
+	},
+	{
+		desc: "REVERSED: descending markers without the option",
+		md: `3. Three
+2. Two
+`,
+		html: `<ol class="fancy fl-num" type="1" start="3">
+<li>Three</li>
+<li>Two</li>
+</ol>`,
+	},
+	{
+		desc:    "BULLETS: classes follow the nesting depth",
+		options: []Option{WithBulletClasses()},
+		md: `- One
+  1. Nested
+     - Deeper
+       * Deepest
+`,
+		html: `<ul class="fl-disc">
+<li>One
+<ol class="fancy fl-num" type="1" start="1">
+<li>Nested
+<ul class="fl-square">
+<li>Deeper
+<ul class="fl-square">
+<li>Deepest</li>
+</ul>
+</li>
+</ul>
+</li>
+</ol>
+</li>
+</ul>`,
+	},
+	{
+		desc:    "BULLETS: a bullet list nested in a bullet list is a circle",
+		options: []Option{WithBulletClasses()},
+		md: `- One
+  - Nested
+`,
+		html: `<ul class="fl-disc">
+<li>One
+<ul class="fl-circle">
+<li>Nested</li>
+</ul>
+</li>
+</ul>`,
+	},
+	{
+		desc:            "BULLETS: user classes follow the bullet class",
+		options:         []Option{WithBulletClasses()},
+		blockAttributes: true,
+		md: `- One
+{.todo}
+`,
+		html: `<ul class="fl-disc todo">
+<li>One</li>
+</ul>`,
+	},
+	{
+		desc:    "PASSTHROUGH: bullet lists are left to the core parser",
+		options: []Option{WithBulletPassthrough(), WithBulletClasses()},
+		md: `- One
+  a. Nested
+  b. Nested
+- Two
+`,
+		html: `<ul>
+<li>One
+<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>Nested</li>
+<li>Nested</li>
+</ol>
+</li>
+<li>Two</li>
+</ul>`,
+	},
+	{
+		desc:    "PASSTHROUGH: a bullet line ends a fancy list",
+		options: []Option{WithBulletPassthrough()},
+		md: `a. One
+- Two
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>One</li>
+</ol>
+<ul>
+<li>Two</li>
+</ul>`,
+	},
+	{
+		desc:    "DELIMITERS: classes record the delimiter of each list",
+		options: []Option{WithDelimiterClasses()},
+		md: `a) One
+b) Two
+
+1. One
+`,
+		html: `<ol class="fancy fl-lcalpha fl-paren" type="a" start="1">
+<li>One</li>
+<li>Two</li>
+</ol>
+<ol class="fancy fl-num fl-period" type="1" start="1">
+<li>One</li>
+</ol>`,
+	},
+	{
+		desc:    "DELIMITERS: full-width delimiters count as ASCII",
+		options: []Option{WithDelimiterClasses(), WithFullWidthMarkers()},
+		md: `ａ） One
+`,
+		html: `<ol class="fancy fl-lcalpha fl-paren" type="a" start="1">
+<li>One</li>
+</ol>`,
+	},
+	{
+		desc:    "MARKERS: marker spans in a bullet list element",
+		options: []Option{WithMarkerSpans(MarkerSpansList)},
+		md: `iv. Four
+#. Five
+`,
+		html: `<ul class="fancy fl-lcroman fl-marked">
+<li><span class="fl-marker">iv.</span> Four</li>
+<li><span class="fl-marker">v.</span> Five</li>
+</ul>`,
+	},
+	{
+		desc:    "MARKERS: marker spans in an unnumbered ordered list",
+		options: []Option{WithMarkerSpans(MarkerSpansOrdered)},
+		md: `Y) One
+#) Two
+
+08. Eight
+#. Nine
+`,
+		html: `<ol class="fancy fl-ucalpha fl-marked">
+<li><span class="fl-marker">Y)</span> One</li>
+<li><span class="fl-marker">Z)</span> Two</li>
+</ol>
+<ol class="fancy fl-num fl-marked">
+<li><span class="fl-marker">08.</span> Eight</li>
+<li><span class="fl-marker">09.</span> Nine</li>
+</ol>`,
+	},
+	{
+		desc:    "MARKERS: bullet lists are unchanged",
+		options: []Option{WithMarkerSpans(MarkerSpansList)},
+		md: `- One
+`,
+		html: `<ul>
+<li>One</li>
+</ul>`,
+	},
+	{
+		desc:            "MINIMAL: only the type attribute is written",
+		options:         []Option{WithMinimalOutput()},
+		blockAttributes: true,
+		md: `a. One
+b. Two
+{.steps data-x="1"}
+
+1. One
+`,
+		html: `<ol type="a">
+<li>One</li>
+<li>Two</li>
+</ol>
+<ol>
+<li>One</li>
+</ol>`,
+	},
+	{
+		desc:    "MINIMAL: items carry the numbering instead of start",
+		options: []Option{WithMinimalOutput()},
+		md: `III. Three
+#. Four
+
+- Bullet
+`,
+		html: `<ol type="I">
+<li value="3">Three</li>
+<li value="4">Four</li>
+</ol>
+<ul>
+<li>Bullet</li>
+</ul>`,
+	},
+	{
+		desc:    "MICRODATA: lists and items carry schema.org ItemList microdata",
+		options: []Option{WithMicrodata()},
+		md: `c. Three
+d. Four
+
+- One
+- Two
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="3" ` +
+			`itemscope itemtype="https://schema.org/ItemList">
+` + microdataItem("3", "Three") + microdataItem("4", "Four") + `</ol>
+<ul itemscope itemtype="https://schema.org/ItemList">
+` + microdataItem("1", "One") + microdataItem("2", "Two") + `</ul>`,
+	},
+	{
+		desc:    "MICRODATA: minimal output is not annotated",
+		options: []Option{WithMicrodata(), WithMinimalOutput()},
+		md: `a. One
+`,
+		html: `<ol type="a">
+<li>One</li>
+</ol>`,
+	},
+	{
+		desc:    "STEPS: step markers form an ordered list",
+		options: []Option{WithStepMarkers()},
+		md: `Step 1. Boil water
+Step 2. Steep
+#. Serve
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1" data-prefix="Step ">
+<li>Boil water</li>
+<li>Steep</li>
+<li>Serve</li>
+</ol>`,
+	},
+	{
+		desc:    "STEPS: a marker without the prefix starts a new list",
+		options: []Option{WithStepMarkers()},
+		md: `step a) One
+b) Two
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1" data-prefix="step ">
+<li>One</li>
+</ol>
+<ol class="fancy fl-lcalpha" type="a" start="2">
+<li>Two</li>
+</ol>`,
+	},
+	{
+		desc:    "STEPS: marker spans include the prefix",
+		options: []Option{WithStepMarkers(), WithMarkerSpans(MarkerSpansOrdered)},
+		md: `Step 1. Boil water
+#. Steep
+`,
+		html: `<ol class="fancy fl-num fl-marked" data-prefix="Step ">
+<li><span class="fl-marker">Step 1.</span> Boil water</li>
+<li><span class="fl-marker">Step 2.</span> Steep</li>
+</ol>`,
+	},
+	{
+		desc: "STEPS: without the option step lines are text",
+		md: `Step 1. Boil water
+`,
+		html: `<p>Step 1. Boil water</p>`,
+	},
+	{
+		desc:    "PREFIXES: custom prefixes with and without a space",
+		options: []Option{WithMarkerPrefixes("Q", "Task ")},
+		md: `Q1. First question
+Q2. Second question
+
+Task 3) Write
+Task 4) Review
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1" data-prefix="Q">
+<li>First question</li>
+<li>Second question</li>
+</ol>
+<ol class="fancy fl-num" type="1" start="3" data-prefix="Task ">
+<li>Write</li>
+<li>Review</li>
+</ol>`,
+	},
+	{
+		desc:    "PREFIXES: words starting with a prefix are not markers",
+		options: []Option{WithMarkerPrefixes("Task ")},
+		md: `Task force. Not a list
+`,
+		html: `<p>Task force. Not a list</p>`,
+	},
+	{
+		desc:    "LEGAL: section markers",
+		options: []Option{WithLegalMarkers()},
+		md: `§ 1. Scope
+§ 2. Definitions
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1" data-prefix="§ ">
+<li>Scope</li>
+<li>Definitions</li>
+</ol>`,
+	},
+	{
+		desc:    "LEGAL: article markers read roman numerals first",
+		options: []Option{WithLegalMarkers()},
+		md: `Article V. Term
+Article VI. Termination
+`,
+		html: `<ol class="fancy fl-ucroman" type="I" start="5" data-prefix="Article ">
+<li>Term</li>
+<li>Termination</li>
+</ol>`,
+	},
+	{
+		desc:    "LEGAL: article markers that are not roman numerals are letters",
+		options: []Option{WithLegalMarkers()},
+		md: `Article A. Annex
+`,
+		html: `<ol class="fancy fl-ucalpha" type="A" start="1" data-prefix="Article ">
+<li>Annex</li>
+</ol>`,
+	},
+	{
+		desc:    "ORDINAL: suffix markers are numeric",
+		options: []Option{WithOrdinalMarkers()},
+		md: `1º Introducción
+2º Objetivos
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1" data-suffix="º">
+<li>Introducción</li>
+<li>Objetivos</li>
+</ol>`,
+	},
+	{
+		desc:    "ORDINAL: suffix with delimiter and start",
+		options: []Option{WithOrdinalMarkers()},
+		md: `3ª. Terza
+4ª. Quarta
+`,
+		html: `<ol class="fancy fl-num" type="1" start="3" data-suffix="ª">
+<li>Terza</li>
+<li>Quarta</li>
+</ol>`,
+	},
+	{
+		desc:    "ORDINAL: a different suffix starts a new list",
+		options: []Option{WithOrdinalMarkers()},
+		md: `1° uno
+2. due
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1" data-suffix="°">
+<li>uno</li>
+</ol>
+<ol class="fancy fl-num" type="1" start="2">
+<li>due</li>
+</ol>`,
+	},
+	{
+		desc:    "ORDINAL: degree sign needs a space after it",
+		options: []Option{WithOrdinalMarkers()},
+		md: `20°C outside
+`,
+		html: `<p>20°C outside</p>`,
+	},
+	{
+		desc: "ORDINAL: suffix markers are paragraphs by default",
+		md: `1º Introducción
+`,
+		html: `<p>1º Introducción</p>`,
+	},
+	{
+		desc:    "DOUBLE: combined delimiters are normalized to periods",
+		options: []Option{WithDoubleDelimiters()},
+		md: `1.) First
+2.) Second
+3. Third
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1">
+<li>First</li>
+<li>Second</li>
+<li>Third</li>
+</ol>`,
+	},
+	{
+		desc:    "DOUBLE: letters and roman numerals",
+		options: []Option{WithDoubleDelimiters()},
+		md: `b.) Bee
+c.) Sea
+
+ii.) Two
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="2">
+<li>Bee</li>
+<li>Sea</li>
+</ol>
+<ol class="fancy fl-lcroman" type="i" start="2">
+<li>Two</li>
+</ol>`,
+	},
+	{
+		desc:    "DOUBLE: marker spans keep the written form",
+		options: []Option{WithDoubleDelimiters(), WithMarkerSpans(MarkerSpansOrdered)},
+		md: `1.) First
+`,
+		html: `<ol class="fancy fl-num fl-marked">
+<li><span class="fl-marker">1.)</span> First</li>
+</ol>`,
+	},
+	{
+		desc: "DOUBLE: combined delimiters are paragraphs by default",
+		md: `1.) First
+`,
+		html: `<p>1.) First</p>`,
+	},
+	{
+		desc:    "HASHDEPTH: bare hash lists are numbered by depth",
+		options: []Option{WithHashDepthTypes()},
+		md: `#. One
+   #. Sub
+      #. Deeper
+         #. Deepest
+            #. Again
+#. Two
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1">
+<li>One
+<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>Sub
+<ol class="fancy fl-lcroman" type="i" start="1">
+<li>Deeper
+<ol class="fancy fl-ucalpha" type="A" start="1">
+<li>Deepest
+<ol class="fancy fl-num" type="1" start="1">
+<li>Again</li>
+</ol>
+</li>
+</ol>
+</li>
+</ol>
+</li>
+</ol>
+</li>
+<li>Two</li>
+</ol>`,
+	},
+	{
+		desc:    "HASHDEPTH: a custom cycle",
+		options: []Option{WithHashDepthTypes(UpperRoman, UpperAlpha)},
+		md: `#. One
+   #. Sub
+      #. Deeper
+`,
+		html: `<ol class="fancy fl-ucroman" type="I" start="1">
+<li>One
+<ol class="fancy fl-ucalpha" type="A" start="1">
+<li>Sub
+<ol class="fancy fl-ucroman" type="I" start="1">
+<li>Deeper</li>
+</ol>
+</li>
+</ol>
+</li>
+</ol>`,
+	},
+	{
+		desc:    "HASHDEPTH: explicit markers keep their type",
+		options: []Option{WithHashDepthTypes()},
+		md: `#. One
+   A. Sub
+   #. Next
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1">
+<li>One
+<ol class="fancy fl-ucalpha" type="A" start="1">
+<li>Sub</li>
+<li>Next</li>
+</ol>
+</li>
+</ol>`,
+	},
+	{
+		desc:    "HASH DEPTH: an empty type list set through the field numbers as usual",
+		options: []Option{func(e *FancyListsOptions) { e.HashDepthTypes = []MarkerType{} }},
+		md: `#. a
+    #. b
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1">
+<li>a
+<ol class="fancy fl-num" type="1" start="1">
+<li>b</li>
+</ol>
+</li>
+</ol>`,
+	},
+	{
+		desc:    "OUTLINE: nested bullets become outline numbering",
+		options: []Option{WithOutlineNumbering()},
+		md: `- One
+  - Sub
+    - Deeper
+- Two
+
+Flat:
+
+- Flat
+- List
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1">
+<li>One
+<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>Sub
+<ol class="fancy fl-lcroman" type="i" start="1">
+<li>Deeper</li>
+</ol>
+</li>
+</ol>
+</li>
+<li>Two</li>
+</ol>
+<p>Flat:</p>
+<ul>
+<li>Flat</li>
+<li>List</li>
+</ul>`,
+	},
+	{
+		desc:    "OUTLINE: ordered lists keep their type and count toward depth",
+		options: []Option{WithOutlineNumbering(UpperRoman, UpperAlpha, Numeric)},
+		md: `* One
+  1. Sub
+     * Deeper
+`,
+		html: `<ol class="fancy fl-ucroman" type="I" start="1">
+<li>One
+<ol class="fancy fl-num" type="1" start="1">
+<li>Sub
+<ol class="fancy fl-num" type="1" start="1">
+<li>Deeper</li>
+</ol>
+</li>
+</ol>
+</li>
+</ol>`,
+	},
+	{
+		desc:    "HEADINGS: headings are numbered like nested lists",
+		options: []Option{WithHeadingNumbers(HeadingNumbersText)},
+		md: `## Intro
+
+### Scope
+
+#### Terms
+
+#### Notes
+
+## Usage
+
+#
+`,
+		html: `<h2>1 Intro</h2>
+<h3>1.1 Scope</h3>
+<h4>1.1.a Terms</h4>
+<h4>1.1.b Notes</h4>
+<h2>2 Usage</h2>
+<h1>3</h1>`,
+	},
+	{
+		desc:    "HEADINGS: numbers as data attributes with custom types",
+		options: []Option{WithHeadingNumbers(HeadingNumbersAttribute, UpperRoman, UpperAlpha)},
+		md: `# Part
+
+## Section
+
+# Part
+`,
+		html: `<h1 data-number="I">Part</h1>
+<h2 data-number="I.A">Section</h2>
+<h1 data-number="II">Part</h1>`,
+	},
+	{
+		desc:    "QUIZ: uppercase alpha lists with a quiz attribute become radio groups",
+		options: []Option{WithQuizzes(), WithBlockAttributes()},
+		md: `Capital of France?
+
+A. Berlin
+B. *Paris*
+{quiz=q1 .hard}
+`,
+		html: `<p>Capital of France?</p>
+<ol class="fancy fl-ucalpha fl-quiz hard" type="A" start="1">
+<li><label><input type="radio" name="q1" value="A"> Berlin</label></li>
+<li><label><input type="radio" name="q1" value="B"> <em>Paris</em></label></li>
+</ol>`,
+	},
+	{
+		desc:    "QUIZ: other lists keep the attribute",
+		options: []Option{WithQuizzes(), WithBlockAttributes()},
+		md: `1. One
+{quiz=q2}
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1" quiz="q2">
+<li>One</li>
+</ol>`,
+	},
+	{
+		desc:    "QUIZ: the attribute is ignored without the option",
+		options: []Option{WithBlockAttributes()},
+		md: `A. One
+{quiz=q3}
+`,
+		html: `<ol class="fancy fl-ucalpha" type="A" start="1" quiz="q3">
+<li>One</li>
+</ol>`,
+	},
+	{
+		desc:    "COMMENTS: a comment between lists continues the numbering",
+		options: []Option{WithCommentContinuation()},
+		md: `a. One
+b. Two
+
+<!-- reviewed -->
+
+a. Three
+#. Four
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>One</li>
+<li>Two
+<!-- raw HTML omitted -->
+</li>
+<li>Three</li>
+<li>Four</li>
+</ol>`,
+	},
+	{
+		desc:    "COMMENTS: lists of different types stay apart",
+		options: []Option{WithCommentContinuation()},
+		md: `1. One
+
+<!-- note -->
+
+a. Two
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1">
+<li>One</li>
+</ol>
+<!-- raw HTML omitted -->
+<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>Two</li>
+</ol>`,
+	},
+	{
+		desc: "COMMENTS: comments end lists by default",
+		md: `1. One
+
+<!-- note -->
+
+1. Two
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1">
+<li>One</li>
+</ol>
+<!-- raw HTML omitted -->
+<ol class="fancy fl-num" type="1" start="1">
+<li>Two</li>
+</ol>`,
+	},
+	{
+		desc:    "BLANKLINES: two blank lines end the list",
+		options: []Option{WithDoubleBlankLineEnd()},
+		md: `a. One
+   - Nested
+
+
+   Indented text
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>One
+<ul>
+<li>Nested</li>
+</ul>
+</li>
+</ol>
+<p>Indented text</p>`,
+	},
+	{
+		desc:    "BLANKLINES: one blank line keeps the list open",
+		options: []Option{WithDoubleBlankLineEnd()},
+		md: `a. One
+
+   More
+b. Two
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>
+<p>One</p>
+<p>More</p>
+</li>
+<li>
+<p>Two</p>
+</li>
+</ol>`,
+	},
+	{
+		desc:    "BLANKLINES: blank lines in fenced code do not end the list",
+		options: []Option{WithDoubleBlankLineEnd()},
+		md:      "a. One\n   ```\n   x\n\n\n   y\n   ```\nb. Two\n",
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>One
+<pre><code>x
+
+
+y
+</code></pre>
+</li>
+<li>Two</li>
+</ol>`,
+	},
+	{
+		desc:    "BLANKLINES: two quoted blank lines end a list in a block quote",
+		options: []Option{WithDoubleBlankLineEnd()},
+		md:      "> a. One\n>\n>\n>    Indented text\n",
+		html:    `<blockquote>
+<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>One</li>
+</ol>
+<p>Indented text</p>
+</blockquote>`,
+	},
+	{
+		desc: "BLANKLINES: indented text after two blank lines continues the item by default",
+		md: `a. One
+
+
+   Indented text
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>
+<p>One</p>
+<p>Indented text</p>
+</li>
+</ol>`,
+	},
+	{
+		desc:    "SPACING: {loose} makes a tight list loose",
+		options: []Option{WithBlockAttributes()},
+		md: `a. One
+b. *Two*
+{loose}
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>
+<p>One</p>
+</li>
+<li>
+<p><em>Two</em></p>
+</li>
+</ol>`,
+	},
+	{
+		desc:    "SPACING: {tight} makes a loose list tight",
+		options: []Option{WithBlockAttributes()},
+		md: `a. One
+
+b. Two
+{tight}
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>One</li>
+<li>Two</li>
+</ol>`,
+	},
+	{
+		desc:            "SPACING: loose and tight classes from goldmark-attributes are user classes",
+		blockAttributes: true,
+		md: `a. One
+b. Two
+{.loose .extra}
+
+- One
+
+- Two
+{.tight}
+`,
+		html: `<ol class="fancy fl-lcalpha loose extra" type="a" start="1">
+<li>One</li>
+<li>Two</li>
+</ol>
+<ul class="tight">
+<li>
+<p>One</p>
+</li>
+<li>
+<p>Two</p>
+</li>
+</ul>`,
+	},
+	{
+		desc:    "SPACING: only the keywords change spacing with block attributes",
+		options: []Option{WithBlockAttributes()},
+		md: `a. One
+
+b. Two
+{.tight .x}
+`,
+		html: `<ol class="fancy fl-lcalpha tight x" type="a" start="1">
+<li>
+<p>One</p>
+</li>
+<li>
+<p>Two</p>
+</li>
+</ol>`,
+	},
+	{
+		desc:    "LAZY: unindented lines continue the item by default",
+		options: []Option{WithLazyContinuation(LazyCommonMark)},
+		md: `a. One
+lazy line
+b. Two
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>One
+lazy line</li>
+<li>Two</li>
+</ol>`,
+	},
+	{
+		desc:    "LAZY: indented mode ends the list at an unindented line",
+		options: []Option{WithLazyContinuation(LazyIndented)},
+		md: `a. One
+   indented line
+lazy line
+# Heading
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>One
+indented line</li>
+</ol>
+<p>lazy line</p>
+<h1>Heading</h1>`,
+	},
+	{
+		desc:    "LAZY: indented mode in nested lists",
+		options: []Option{WithLazyContinuation(LazyIndented)},
+		md: `1. One
+   a. Sub
+   lazy for the sub item
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1">
+<li>One
+<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>Sub</li>
+</ol>
+lazy for the sub item</li>
+</ol>`,
+	},
+	{
+		desc: "HANGING: nested content aligns to the marker width by default",
+		md: `iii. One
+  a. Not nested
+`,
+		html: `<ol class="fancy fl-lcroman" type="i" start="3">
+<li>One</li>
+</ol>
+<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>Not nested</li>
+</ol>`,
+	},
+	{
+		desc:    "HANGING: a two-space hanging indent",
+		options: []Option{WithHangingIndent(2)},
+		md: `iii. One
+
+  More of one
+  a. Nested
+iv. Two
+`,
+		html: `<ol class="fancy fl-lcroman" type="i" start="3">
+<li>
+<p>One</p>
+<p>More of one</p>
+<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>Nested</li>
+</ol>
+</li>
+<li>
+<p>Two</p>
+</li>
+</ol>`,
+	},
+	{
+		desc:    "HANGING: a four-space hanging indent",
+		options: []Option{WithHangingIndent(4)},
+		md: `1. One
+   - Not nested
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1">
+<li>One</li>
+</ol>
+<ul>
+<li>Not nested</li>
+</ul>`,
+	},
+	{
+		desc:    "INTERRUPT: letters with any start interrupt a paragraph",
+		options: []Option{WithParagraphInterrupt(InterruptLetters)},
+		md: `Options continue:
+c. Third
+d. Fourth
+
+Then:
+3. Not a list
+`,
+		html: `<p>Options continue:</p>
+<ol class="fancy fl-lcalpha" type="a" start="3">
+<li>Third</li>
+<li>Fourth</li>
+</ol>
+<p>Then:
+3. Not a list</p>`,
+	},
+	{
+		desc:    "INTERRUPT: any start interrupts a paragraph",
+		options: []Option{WithParagraphInterrupt(InterruptAny)},
+		md: `Steps continue:
+3. Third
+iv. Fourth
+`,
+		html: `<p>Steps continue:</p>
+<ol class="fancy fl-num" type="1" start="3">
+<li>Third</li>
+</ol>
+<ol class="fancy fl-lcroman" type="i" start="4">
+<li>Fourth</li>
+</ol>`,
+	},
+	{
+		desc: "INTERRUPT: only lists from one interrupt by default",
+		md: `Options continue:
+c. Third
+`,
+		html: `<p>Options continue:
+c. Third</p>`,
+	},
+	{
+		desc:    "LARGEROMAN: numerals above 3999 are accepted by default",
+		options: []Option{WithLegalMarkers()},
+		md: `Article MMMMI. Far
+`,
+		html: `<ol class="fancy fl-ucroman" type="I" start="4001" data-prefix="Article ">
+<li>Far</li>
+</ol>`,
+	},
+	{
+		desc:    "LARGEROMAN: markers starting with M are large numerals by default",
+		options: []Option{},
+		md: `MMMMI. Far
+MMMMII. Farther
+`,
+		html: `<ol class="fancy fl-ucroman" type="I" start="4001">
+<li>Far</li>
+<li>Farther</li>
+</ol>`,
+	},
+	{
+		desc:    "LARGEROMAN: markers starting with M follow the policy",
+		options: []Option{WithLargeRoman(LargeRomanAlpha)},
+		md: `MMMM. Far
+
+MMMMI. Farther
+`,
+		html: `<ol class="fancy fl-ucalpha" type="A" start="237627">
+<li>Far</li>
+</ol>
+<p>MMMMI. Farther</p>`,
+	},
+	{
+		desc:    "LARGEROMAN: markers starting with M are rejected with the policy",
+		options: []Option{WithLargeRoman(LargeRomanReject)},
+		md: `MMMMI. Far
+`,
+		html: `<p>MMMMI. Far</p>`,
+	},
+	{
+		desc:    "LARGEROMAN: numerals above 3999 fall back to letters",
+		options: []Option{WithLegalMarkers(), WithLargeRoman(LargeRomanAlpha)},
+		md: `Article MMMM. Far
+
+Article MMMMI. Farther
+`,
+		html: `<ol class="fancy fl-ucalpha" type="A" start="237627" data-prefix="Article ">
+<li>Far</li>
+</ol>
+<p>Article MMMMI. Farther</p>`,
+	},
+	{
+		desc:    "LARGEROMAN: numerals above 3999 are rejected",
+		options: []Option{WithLegalMarkers(), WithLargeRoman(LargeRomanReject)},
+		md: `Article MMMMI. Far
+
+Article MMM. Near
+Article MMMM. Far
+`,
+		html: `<p>Article MMMMI. Far</p>
+<ol class="fancy fl-ucroman" type="I" start="3000" data-prefix="Article ">
+<li>Near
+Article MMMM. Far</li>
+</ol>`,
+	},
+	{
+		desc:    "UNICODEROMAN: uppercase numeral characters",
+		options: []Option{WithUnicodeRoman()},
+		md:      "Ⅺ. eleven\nⅫ. twelve",
+		html:    `<ol class="fancy fl-ucroman" type="I" start="11">
+<li>eleven</li>
+<li>twelve</li>
+</ol>
+`,
+	},
+	{
+		desc:    "UNICODEROMAN: lowercase sequence is roman, not alphabetic",
+		options: []Option{WithUnicodeRoman()},
+		md:      "ⅹ) ten\nⅹⅰ) eleven",
+		html:    `<ol class="fancy fl-lcroman" type="i" start="10">
+<li>ten</li>
+<li>eleven</li>
+</ol>
+`,
+	},
+	{
+		desc:    "UNICODEROMAN: mixed case is not a marker",
+		options: []Option{WithUnicodeRoman()},
+		md:      "Ⅹⅰ. text",
+		html:    `<p>Ⅹⅰ. text</p>
+`,
+	},
+	{
+		desc:    "UNICODEROMAN: off by default",
+		options: []Option{},
+		md:      "Ⅲ. three",
+		html:    `<p>Ⅲ. three</p>
+`,
+	},
+	{
+		desc:    "PARENTHESIZED: digits up to twenty",
+		options: []Option{WithParenthesizedMarkers()},
+		md:      "⑵ two\n⑶ three\n⒇ twenty",
+		html:    `<ol class="fancy fl-num" type="1" start="2">
+<li>two</li>
+<li>three</li>
+<li>twenty</li>
+</ol>
+`,
+	},
+	{
+		desc:    "PARENTHESIZED: letters continue with ASCII markers",
+		options: []Option{WithParenthesizedMarkers()},
+		md:      "⒝ bee\nc) sea\n\n🄓 dee",
+		html:    `<ol class="fancy fl-lcalpha" type="a" start="2">
+<li>bee</li>
+<li>sea</li>
+</ol>
+<ol class="fancy fl-ucalpha" type="A" start="4">
+<li>dee</li>
+</ol>
+`,
+	},
+	{
+		desc:    "PARENTHESIZED: content must follow a space",
+		options: []Option{WithParenthesizedMarkers()},
+		md:      "⑴text",
+		html:    `<p>⑴text</p>
+`,
+	},
+	{
+		desc:    "PARENTHESIZED: off by default",
+		options: []Option{},
+		md:      "⑴ one",
+		html:    `<p>⑴ one</p>
+`,
+	},
+	{
+		desc:    "BULLETS: configured bullet characters",
+		options: []Option{WithBullets("•◦▪")},
+		md:      "• one\n• two\n  ◦ nested\n  ◦ again",
+		html:    `<ul>
+<li>one</li>
+<li>two
+<ul>
+<li>nested</li>
+<li>again</li>
+</ul>
+</li>
+</ul>
+`,
+	},
+	{
+		desc:    "BULLETS: changing the bullet starts a new list",
+		options: []Option{WithBullets("•◦▪")},
+		md:      "• one\n▪ two\n- three",
+		html:    `<ul>
+<li>one</li>
+</ul>
+<ul>
+<li>two</li>
+</ul>
+<ul>
+<li>three</li>
+</ul>
+`,
+	},
+	{
+		desc:    "BULLETS: unconfigured and ASCII characters are ignored",
+		options: []Option{WithBullets("•x")},
+		md:      "◦ one\n\nx two",
+		html:    `<p>◦ one</p>
+<p>x two</p>
+`,
+	},
+	{
+		desc:    "BULLETS: off by default",
+		options: []Option{},
+		md:      "• one",
+		html:    `<p>• one</p>
+`,
+	},
+	{
+		desc:    "DASHBULLETS: en and em dashes",
+		options: []Option{WithDashBullets()},
+		md:      "– one\n– two\n\n— three",
+		html:    `<ul>
+<li>one</li>
+<li>two</li>
+</ul>
+<ul>
+<li>three</li>
+</ul>
+`,
+	},
+	{
+		desc:    "DASHBULLETS: a dash needs a trailing space",
+		options: []Option{WithDashBullets()},
+		md:      "—quote",
+		html:    `<p>—quote</p>
+`,
+	},
+	{
+		desc:    "DASHBULLETS: combined with other bullets",
+		options: []Option{WithBullets("•"), WithDashBullets()},
+		md:      "• one\n  – nested",
+		html:    `<ul>
+<li>one
+<ul>
+<li>nested</li>
+</ul>
+</li>
+</ul>
+`,
+	},
+	{
+		desc:    "ALPHANUMBERING: bijective by default",
+		options: []Option{},
+		md:      "aa. one\n\nba) two",
+		html:    `<ol class="fancy fl-lcalpha" type="a" start="27">
+<li>one</li>
+</ol>
+<ol class="fancy fl-lcalpha" type="a" start="53">
+<li>two</li>
+</ol>
+`,
+	},
+	{
+		desc:    "ALPHANUMBERING: positional",
+		options: []Option{WithAlphaNumbering(AlphaPositional)},
+		md:      "aa. one\n\nba) two\n\nZ. three",
+		html:    `<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>one</li>
+</ol>
+<ol class="fancy fl-lcalpha" type="a" start="27">
+<li>two</li>
+</ol>
+<ol class="fancy fl-ucalpha" type="A" start="26">
+<li>three</li>
+</ol>
+`,
+	},
+	{
+		desc:    "ALPHANUMBERING: positional marker spans",
+		options: []Option{WithAlphaNumbering(AlphaPositional), WithMarkerSpans(MarkerSpansList)},
+		md:      "z. one\nba. two\nbb. three",
+		html:    `<ul class="fancy fl-lcalpha fl-marked">
+<li><span class="fl-marker">z.</span> one</li>
+<li><span class="fl-marker">ba.</span> two</li>
+<li><span class="fl-marker">bb.</span> three</li>
+</ul>
+`,
+	},
+	{
+		desc:    "ABBREVIATIONS: default stop-list",
+		options: []Option{WithAbbreviations()},
+		md:      "vs. the others\n\nNo. 5 was late\n\nb. item",
+		html:    `<p>vs. the others</p>
+<p>No. 5 was late</p>
+<ol class="fancy fl-lcalpha" type="a" start="2">
+<li>item</li>
+</ol>
+`,
+	},
+	{
+		desc:    "ABBREVIATIONS: custom stop-list replaces the default",
+		options: []Option{WithAbbreviations("approx.")},
+		md:      "approx. ten\n\nvs. the others",
+		html:    `<p>approx. ten</p>
+<ol class="fancy fl-lcalpha" type="a" start="591">
+<li>the others</li>
+</ol>
+`,
+	},
+	{
+		desc:    "ABBREVIATIONS: the default stop-list applies without options",
+		options: []Option{},
+		md:      "etc. and so on\n\nvs. them",
+		html:    `<p>etc. and so on</p>
+<p>vs. them</p>`,
+	},
+	{
+		desc:    "ABBREVIATIONS: the stop-list can be turned off",
+		options: []Option{WithoutAbbreviations()},
+		md:      "etc. and so on",
+		html:    `<ol class="fancy fl-lcalpha" type="a" start="3903">
+<li>and so on</li>
+</ol>
+`,
+	},
+	{
+		desc:    "REGIONS: fancy markers only inside the region",
+		options: []Option{WithFancyRegions("")},
+		md:      "a. outside\n\n::: fancy\na. inside\nb. two\n:::\n\nc. after",
+		html:    `<p>a. outside</p>
+<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>inside</li>
+<li>two</li>
+</ol>
+<p>c. after</p>
+`,
+	},
+	{
+		desc:    "REGIONS: CommonMark lists outside render as core",
+		options: []Option{WithFancyRegions("")},
+		md:      "3. three\n4. four\n\n- bullet\n\n::: fancy\n3. three\n:::",
+		html:    `<ol start="3">
+<li>three</li>
+<li>four</li>
+</ol>
+<ul>
+<li>bullet</li>
+</ul>
+<ol class="fancy fl-num" type="1" start="3">
+<li>three</li>
+</ol>
+`,
+	},
+	{
+		desc:    "REGIONS: fancy marker outside is a lazy continuation line",
+		options: []Option{WithFancyRegions("")},
+		md:      "1. one\na. not an item",
+		html:    `<ol>
+<li>one
+a. not an item</li>
+</ol>
+`,
+	},
+	{
+		desc:    "REGIONS: nested lists and block quotes inside the region",
+		options: []Option{WithFancyRegions("")},
+		md:      "> ::: fancy :::\n> A) first\n>\n>    i. nested\n> :::\n>\n> B) quoted",
+		html:    `<blockquote>
+<ol class="fancy fl-ucalpha" type="A" start="1">
+<li>
+<p>first</p>
+<ol class="fancy fl-lcroman" type="i" start="1">
+<li>nested</li>
+</ol>
+</li>
+</ol>
+<p>B) quoted</p>
+</blockquote>
+`,
+	},
+	{
+		desc:    "REGIONS: custom region name",
+		options: []Option{WithFancyRegions("steps")},
+		md:      "::: fancy\na. not a region\n:::\n\n:::: steps\ni. first\n::::",
+		html:    `<p>::: fancy
+a. not a region
+:::</p>
+<ol class="fancy fl-lcroman" type="i" start="1">
+<li>first</li>
+</ol>
+`,
+	},
+	{
+		desc:    "REGIONS: unclosed region runs to the end",
+		options: []Option{WithFancyRegions("")},
+		md:      "::: fancy\n#. one\n#. two",
+		html:    `<ol class="fancy fl-num" type="1" start="1">
+<li>one</li>
+<li>two</li>
+</ol>
+`,
+	},
+	{
+		desc:    "INLINESTYLES: a style attribute alongside the classes",
+		options: []Option{WithInlineStyles(InlineStylesWithClasses), WithBulletClasses()},
+		md: `i. One
+    - Nested
+ii. Two
+`,
+		html: `<ol class="fancy fl-lcroman" type="i" start="1" style="list-style-type: lower-roman">
+<li>One
+<ul class="fl-circle" style="list-style-type: circle">
+<li>Nested</li>
+</ul>
+</li>
+<li>Two</li>
+</ol>`,
+	},
+	{
+		desc:            "INLINESTYLES: a style attribute instead of the computed classes",
+		options:         []Option{WithInlineStyles(InlineStylesOnly), WithDelimiterClasses()},
+		blockAttributes: true,
+		md: `B) One
+C) Two
+{.steps style="color: red"}
+`,
+		html: `<ol class="steps" type="A" start="2" style="list-style-type: upper-alpha; color: red">
+<li>One</li>
+<li>Two</li>
+</ol>`,
+	},
+	{
+		desc:    "INLINESTYLES: plain bullet lists get no style",
+		options: []Option{WithInlineStyles(InlineStylesOnly)},
+		md: `- One
+- Two
+`,
+		html: `<ul>
+<li>One</li>
+<li>Two</li>
+</ul>`,
+	},	{
+		desc:            "SEMANTIC: only type and start, omitted when they are the default",
+		options:         []Option{WithSemanticOutput(), WithDelimiterClasses()},
+		blockAttributes: true,
+		md: `c. One
+d. Two
+{.steps data-x="1"}
+
+1. First
+
+- Bullet
+`,
+		html: `<ol type="a" start="3">
+<li>One</li>
+<li>Two</li>
+</ol>
+<ol>
+<li>First</li>
+</ol>
+<ul>
+<li>Bullet</li>
+</ul>`,
+	},
+	{
+		desc:    "SEMANTIC: reversed lists and explicit values",
+		options: []Option{WithSemanticOutput(), WithReversedLists(), WithMarkerSpans(MarkerSpansList)},
+		md: `iii. Three
+ii. Two
+i. One
+
+1. One
+#5. Five
+`,
+		html: `<ol type="i" start="3" reversed>
+<li>Three</li>
+<li>Two</li>
+<li>One</li>
+</ol>
+<ol>
+<li>One</li>
+<li value="5">Five</li>
+</ol>`,
+	},	{
+		desc:    "INDENT: nested lists are indented by depth",
+		options: []Option{WithIndentedOutput("")},
+		md: `a. One
+   - Nested
+     i. Deep
+b. Two
+
+Paragraph
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1">
+  <li>One
+    <ul>
+      <li>Nested
+        <ol class="fancy fl-lcroman" type="i" start="1">
+          <li>Deep</li>
+        </ol>
+      </li>
+    </ul>
+  </li>
+  <li>Two</li>
+</ol>
+<p>Paragraph</p>`,
+	},
+	{
+		desc:    "INDENT: paragraphs of loose items are indented, code blocks are not",
+		options: []Option{WithIndentedOutput("\t")},
+		md: `1. One
+
+   ` + "```" + `
+   code
+   ` + "```" + `
+
+2. Two
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1">
+	<li>
+		<p>One</p>
+<pre><code>code
+</code></pre>
+	</li>
+	<li>
+		<p>Two</p>
+	</li>
+</ol>`,
+	},
+	{
+		desc:    "INDENT: no effect on compact lists",
+		options: []Option{WithIndentedOutput(""), WithCompact()},
+		md: `a. One
+   b. Nested
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1"><li>One
+<ol class="fancy fl-lcalpha" type="a" start="2"><li>Nested</li></ol></li></ol>`,
+	},
+	{
+		desc:    "RENUMBER: lists start at 1 and explicit values are dropped",
+		options: []Option{WithRenumbering()},
+		md: `c. One
+d. Two
+
+7. Seven
+#9. Nine
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a">
+<li>One</li>
+<li>Two</li>
+</ol>
+<ol class="fancy fl-num" type="1">
+<li>Seven</li>
+<li>Nine</li>
+</ol>`,
+	},
+	{
+		desc:    "RENUMBER: reversed lists, item values and marker spans",
+		options: []Option{WithRenumbering(), WithReversedLists(), WithItemValues(), WithMarkerSpans(MarkerSpansOrdered)},
+		md: `iii. Three
+ii. Two
+i. One
+`,
+		html: `<ol class="fancy fl-lcroman fl-marked">
+<li value="1"><span class="fl-marker">i.</span> Three</li>
+<li value="2"><span class="fl-marker">ii.</span> Two</li>
+<li value="3"><span class="fl-marker">iii.</span> One</li>
+</ol>`,
+	},
+	{
+		desc:    "BOUNDARY: comments around fancy and nested lists",
+		options: []Option{WithBoundaryComments()},
+		md: `i. One
+   - Nested
+ii. Two
+`,
+		html: `<!-- fl:start type=lcroman -->
+<ol class="fancy fl-lcroman" type="i" start="1">
+<li>One
+<!-- fl:start type=bullet -->
+<ul>
+<li>Nested</li>
+</ul>
+<!-- fl:end -->
+</li>
+<li>Two</li>
+</ol>
+<!-- fl:end -->`,
+	},
+	{
+		desc:            "BOUNDARY: the type follows a user type and indentation",
+		options:         []Option{WithBoundaryComments(), WithIndentedOutput("")},
+		blockAttributes: true,
+		md: `a. One
+   1. Nested
+{type="I"}
+`,
+		html: `<!-- fl:start type=ucroman -->
+<ol class="fancy fl-ucroman" type="I" start="1">
+  <li>One
+    <!-- fl:start type=num -->
+    <ol class="fancy fl-num" type="1" start="1">
+      <li>Nested</li>
+    </ol>
+    <!-- fl:end -->
+  </li>
+</ol>
+<!-- fl:end -->`,
+	},
+	{
+		desc:    "BOUNDARY: none around lists rendered as core lists",
+		options: []Option{WithBoundaryComments(), WithCommonMarkOutput()},
+		md: `1. One
+
+A) Two
+`,
+		html: `<ol>
+<li>One</li>
+</ol>
+<!-- fl:start type=ucalpha -->
+<ol class="fancy fl-ucalpha" type="A" start="1">
+<li>Two</li>
+</ol>
+<!-- fl:end -->`,
+	},
+	{
+		desc:    "OMIT NUMERIC TYPE: numeric lists have no type, others keep theirs",
+		options: []Option{WithOmitNumericType()},
+		md: `a. One
+   3. Three
+   4. Four
+b. Two
+`,
+		html: `<ol class="fancy fl-lcalpha" type="a" start="1">
+<li>One
+<ol class="fancy fl-num" start="3">
+<li>Three</li>
+<li>Four</li>
+</ol>
+</li>
+<li>Two</li>
+</ol>`,
+	},
+	{
+		desc:            "OMIT NUMERIC TYPE: a user type is written",
+		options:         []Option{WithOmitNumericType(), WithAttributePolicy(AttributesUserWins)},
+		blockAttributes: true,
+		md: `1. One
+{type="1"}
+`,
+		html: `<ol class="fancy fl-num" type="1" start="1">
+<li>One</li>
+</ol>`,
+	},	{
+		desc:    "ITEM LABELS: roman labels follow hash markers, bullets get none",
+		options: []Option{WithItemLabels()},
+		md: `iii. Three
+#. Four
+#9. Nine
+    - Nested
+`,
+		html: `<ol class="fancy fl-lcroman" type="i" start="3">
+<li data-label="iii">Three</li>
+<li data-label="iv">Four</li>
+<li value="9" data-label="ix">Nine
+<ul>
+<li>Nested</li>
+</ul>
+</li>
+</ol>`,
+	},
+	{
+		desc:    "ITEM LABELS: padding and step prefixes",
+		options: []Option{WithItemLabels(), WithStepMarkers()},
+		md: `08. Eight
+09. Nine
+
+Step c. Three
+Step d. Four
+`,
+		html: `<ol class="fancy fl-num" type="1" start="8">
+<li data-label="08">Eight</li>
+<li data-label="09">Nine</li>
+</ol>
+<ol class="fancy fl-lcalpha" type="a" start="3" data-prefix="Step ">
+<li data-label="c">Three</li>
+<li data-label="d">Four</li>
+</ol>`,
+	},
+	{
+		desc:    "ITEM LABELS: none on core and minimal output",
+		options: []Option{WithItemLabels(), WithCommonMarkOutput(), WithMinimalOutput()},
+		md: `1. One
+
+b. Two
+`,
+		html: `<ol>
+<li>One</li>
+</ol>
+<ol type="a">
+<li value="2">Two</li>
+</ol>`,
+	},
+}
@@ -0,0 +1,69 @@
+package fltest
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/testutil"
+)
+
+// NormalizeHTML returns html in a canonical form for comparison: runs of
+// whitespace become a single space, whitespace between two tags is
+// dropped, and each tag that follows another starts a new line so diffs
+// show one element per line. Whitespace that only separates inline
+// elements, as in "<em>a</em> <b>b</b>", is dropped too, so assertions
+// built on NormalizeHTML do not check it.
+func NormalizeHTML(html string) string {
+	collapsed := strings.Join(strings.Fields(html), " ")
+	var b strings.Builder
+	var last byte
+	for i := 0; i < len(collapsed); i++ {
+		c := collapsed[i]
+		if c == ' ' && last == '>' && i+1 < len(collapsed) && collapsed[i+1] == '<' {
+			continue
+		}
+		if c == '<' && last == '>' {
+			b.WriteByte('\n')
+		}
+		b.WriteByte(c)
+		last = c
+	}
+	return b.String()
+}
+
+// EqualHTML reports whether a and b are the same after NormalizeHTML.
+func EqualHTML(a, b string) bool {
+	return NormalizeHTML(a) == NormalizeHTML(b)
+}
+
+// DiffHTML returns a line diff of the normalized forms of want and got, with
+// removed lines marked "-" and added lines "+", or "" if they are equal.
+func DiffHTML(want, got string) string {
+	w, g := NormalizeHTML(want), NormalizeHTML(got)
+	if w == g {
+		return ""
+	}
+	return string(testutil.DiffPretty([]byte(w), []byte(g)))
+}
+
+// AssertHTML reports an error on t, with a diff, unless got and want are
+// equal after NormalizeHTML.
+func AssertHTML(t testing.TB, got, want string) {
+	t.Helper()
+	if diff := DiffHTML(want, got); diff != "" {
+		t.Errorf("HTML differs (-want +got):\n%s", diff)
+	}
+}
+
+// AssertConvert converts markdown with md and checks the output with
+// AssertHTML.
+func AssertConvert(t testing.TB, md goldmark.Markdown, markdown, want string) {
+	t.Helper()
+	var out bytes.Buffer
+	if err := md.Convert([]byte(markdown), &out); err != nil {
+		t.Fatal(err)
+	}
+	AssertHTML(t, out.String(), want)
+}
@@ -0,0 +1,91 @@
+// Package fltest runs Markdown/HTML golden-file fixtures against a configured
+// Goldmark instance. It lets projects embedding the fancylists extension keep
+// their own regression suites with the comparison the extension's tests use:
+// output and expectation are compared after trimming surrounding whitespace.
+//
+// A fixture is a pair of files in one directory sharing a base name:
+// "name.md" holds the Markdown source and "name.html" the expected output.
+//
+// For hand-written assertions, AssertHTML and AssertConvert compare HTML
+// regardless of whitespace and report a readable diff on mismatch.
+package fltest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/testutil"
+)
+
+// Case is one fixture pair.
+type Case struct {
+	// Name is the base name shared by the fixture files.
+	Name string
+	// Markdown is the source to convert.
+	Markdown string
+	// HTML is the expected output.
+	HTML string
+}
+
+// Load reads every fixture pair in dir, sorted by name. A Markdown file
+// without a matching HTML file is an error, so a missing expectation cannot
+// silently skip a case.
+func Load(dir string) ([]Case, error) {
+	sources, err := filepath.Glob(filepath.Join(dir, "*.md"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(sources)
+	cases := make([]Case, 0, len(sources))
+	for _, source := range sources {
+		markdown, err := os.ReadFile(source)
+		if err != nil {
+			return nil, err
+		}
+		html, err := os.ReadFile(strings.TrimSuffix(source, ".md") + ".html")
+		if err != nil {
+			return nil, fmt.Errorf("fltest: no expected output for %s: %w", source, err)
+		}
+		cases = append(cases, Case{
+			Name:     strings.TrimSuffix(filepath.Base(source), ".md"),
+			Markdown: string(markdown),
+			HTML:     string(html),
+		})
+	}
+	return cases, nil
+}
+
+// Run converts every case with md in its own subtest and reports any output
+// that differs from the expectation, with a diff.
+func Run(t *testing.T, md goldmark.Markdown, cases []Case) {
+	t.Helper()
+	for i, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			testutil.DoTestCase(md, testutil.MarkdownTestCase{
+				No:          i,
+				Description: c.Name,
+				Markdown:    c.Markdown,
+				Expected:    c.HTML,
+			}, t)
+		})
+	}
+}
+
+// RunDir loads the fixtures in dir and runs them with md. It fails the test
+// if the fixtures cannot be loaded or dir holds none.
+func RunDir(t *testing.T, md goldmark.Markdown, dir string) {
+	t.Helper()
+	cases, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) == 0 {
+		t.Fatalf("fltest: no fixtures in %s", dir)
+	}
+	Run(t, md, cases)
+}
@@ -0,0 +1,67 @@
+package fltest_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yuin/goldmark"
+	fancylists "github.com/zmtcreative/gm-fancy-lists"
+	"github.com/zmtcreative/gm-fancy-lists/fltest"
+)
+
+func TestRunDir(t *testing.T) {
+	md := goldmark.New(goldmark.WithExtensions(fancylists.FancyLists))
+	fltest.RunDir(t, md, "testdata")
+}
+
+func TestLoad(t *testing.T) {
+	cases, err := fltest.Load("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) != 2 || cases[0].Name != "alpha" || cases[1].Name != "roman" {
+		t.Errorf("cases = %+v; want alpha and roman", cases)
+	}
+}
+
+func TestLoadMissingExpectation(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "orphan.md"), []byte("a. One\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fltest.Load(dir); err == nil {
+		t.Error("Load accepted a Markdown file without expected output")
+	}
+}
+
+func TestEqualHTML(t *testing.T) {
+	a := "<ol type=\"a\">\n<li>One</li>\n<li>Two\n  words</li>\n</ol>\n"
+	b := "<ol type=\"a\"><li>One</li>   <li>Two words</li></ol>"
+	if !fltest.EqualHTML(a, b) {
+		t.Errorf("EqualHTML(%q, %q) = false", a, b)
+	}
+	if fltest.EqualHTML(a, "<ol type=\"i\"><li>One</li><li>Two words</li></ol>") {
+		t.Error("EqualHTML ignored a changed attribute")
+	}
+}
+
+func TestDiffHTML(t *testing.T) {
+	if diff := fltest.DiffHTML("<p>a</p>", "<p>a</p>\n"); diff != "" {
+		t.Errorf("DiffHTML of equal HTML = %q", diff)
+	}
+	want := "  | <ol>\n- | <li>One</li>\n+ | <li>Uno</li>\n  | </ol>\n"
+	if diff := fltest.DiffHTML("<ol><li>One</li></ol>", "<ol>\n<li>Uno</li>\n</ol>"); diff != want {
+		t.Errorf("DiffHTML = %q, want %q", diff, want)
+	}
+}
+
+func TestAssertConvert(t *testing.T) {
+	md := goldmark.New(goldmark.WithExtensions(fancylists.FancyLists))
+	fltest.AssertConvert(t, md, "b. Two\nc. Three\n",
+		`<ol class="fancy fl-lcalpha" type="a" start="2"><li>Two</li><li>Three</li></ol>`)
+}
+
+func TestRunSpec(t *testing.T) {
+	fltest.RunSpec(t, nil)
+}
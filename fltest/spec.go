@@ -0,0 +1,110 @@
+package fltest
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/testutil"
+	fancylists "github.com/zmtcreative/gm-fancy-lists"
+)
+
+// specCorpus is the conformance corpus, generated from the extension's own
+// test cases by its TestSpecCorpus.
+//
+//go:embed spec/cases.json
+var specCorpus []byte
+
+// SpecCase is one case of the conformance corpus in spec/cases.json.
+type SpecCase struct {
+	// Name identifies the case, such as
+	// "options/maxstart-alphabetic-start-above-the-cap-is-rejected". It is
+	// derived from the description, so it stays the same as cases are
+	// added.
+	Name string `json:"name"`
+	// Description says what the case checks.
+	Description string `json:"description"`
+	// Options holds the fancylists.FancyListsOptions fields the case sets,
+	// by field name; enumerations are written by name, as their MarshalText
+	// methods write them ("clamp", "1|a|i"). Fields that are not listed keep
+	// their default value.
+	Options json.RawMessage `json:"options,omitempty"`
+	// GFM reports that the case also enables Goldmark's GFM, definition
+	// list and footnote extensions.
+	GFM bool `json:"gfm,omitempty"`
+	// Markdown is the source to convert.
+	Markdown string `json:"markdown"`
+	// HTML is the expected output, compared after trimming surrounding
+	// whitespace.
+	HTML string `json:"html"`
+}
+
+// Extension returns the extension configured with the case's options.
+func (c SpecCase) Extension() (*fancylists.FancyListsOptions, error) {
+	e := fancylists.NewFancyLists()
+	if len(c.Options) > 0 {
+		dec := json.NewDecoder(bytes.NewReader(c.Options))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(e); err != nil {
+			return nil, fmt.Errorf("fltest: options of %s: %w", c.Name, err)
+		}
+	}
+	return e, nil
+}
+
+// Spec returns the cases of the conformance corpus. The corpus describes
+// the documented behavior of the extension, alone or alongside GFM, so
+// alternative implementations can check themselves against it;
+// spec/cases.json can also be read directly.
+func Spec() ([]SpecCase, error) {
+	var cases []SpecCase
+	if err := json.Unmarshal(specCorpus, &cases); err != nil {
+		return nil, fmt.Errorf("fltest: reading the spec corpus: %w", err)
+	}
+	return cases, nil
+}
+
+// RunSpec runs every case of the conformance corpus in its own subtest,
+// converting it with the Markdown instance newMarkdown returns for the case.
+// A nil newMarkdown uses Goldmark with this extension, configured by
+// SpecCase.Extension, and the GFM extensions for cases that ask for them.
+func RunSpec(t *testing.T, newMarkdown func(c SpecCase) (goldmark.Markdown, error)) {
+	t.Helper()
+	if newMarkdown == nil {
+		newMarkdown = specMarkdown
+	}
+	cases, err := Spec()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			md, err := newMarkdown(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			testutil.DoTestCase(md, testutil.MarkdownTestCase{
+				No:          i,
+				Description: c.Description,
+				Markdown:    c.Markdown,
+				Expected:    c.HTML,
+			}, t)
+		})
+	}
+}
+
+// specMarkdown is the default Markdown instance of RunSpec.
+func specMarkdown(c SpecCase) (goldmark.Markdown, error) {
+	e, err := c.Extension()
+	if err != nil {
+		return nil, err
+	}
+	if c.GFM {
+		return goldmark.New(goldmark.WithExtensions(e, extension.GFM, extension.DefinitionList, extension.Footnote)), nil
+	}
+	return goldmark.New(goldmark.WithExtensions(e)), nil
+}
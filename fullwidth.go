@@ -0,0 +1,214 @@
+package fancylists
+
+import "unicode/utf8"
+
+// Full-width forms accepted when FullWidthMarkers is enabled.
+const (
+	fullWidthOpenParen  = '（' // U+FF08
+	fullWidthCloseParen = '）' // U+FF09
+	fullWidthPeriod     = '．' // U+FF0E
+	fullWidthHash       = '＃' // U+FF03
+)
+
+// foldFullWidth maps full-width digits and letters to their ASCII forms.
+// Other runes are returned unchanged.
+func foldFullWidth(r rune) rune {
+	switch {
+	case r >= '０' && r <= '９':
+		return r - '０' + '0'
+	case r >= 'Ａ' && r <= 'Ｚ':
+		return r - 'Ａ' + 'A'
+	case r >= 'ａ' && r <= 'ｚ':
+		return r - 'ａ' + 'a'
+	case r == fullWidthHash:
+		return '#'
+	}
+	return r
+}
+
+// parseFullWidthListItem is the counterpart of parseListItem for markers
+// written with full-width characters, as is common in Japanese and Chinese
+// documents: "１）", "ａ．", "（ｉｉ）" or a mix such as "（a）". Markers that
+// contain no full-width character are left to parseListItem.
+func parseFullWidthListItem(line []byte) ([6]int, listItemType) {
+	ret := [6]int{}
+	i := 0
+	l := len(line)
+	for ; i < l && i <= 3 && line[i] == ' '; i++ {
+	}
+	if i > 3 || i >= l {
+		return ret, notList
+	}
+	ret[1] = i
+	ret[2] = i
+
+	fullWidth := false
+	enclosed := false
+	r, size := utf8.DecodeRune(line[i:])
+	if r == fullWidthOpenParen {
+		enclosed = true
+		fullWidth = true
+		i += size
+	}
+
+	var typ listItemType
+	if r, size = utf8.DecodeRune(line[i:]); foldFullWidth(r) == '#' {
+		// A lone '#' continuation marker
+		fullWidth = fullWidth || r == fullWidthHash
+		typ = orderedListFancy
+		i += size
+	} else {
+		digits, letters := 0, 0
+		for i < l {
+			r, size = utf8.DecodeRune(line[i:])
+			folded := foldFullWidth(r)
+			if folded >= utf8.RuneSelf {
+				break
+			}
+			c := byte(folded)
+			if c >= '0' && c <= '9' && letters == 0 {
+				digits++
+			} else if isASCIILetter(c) && digits == 0 {
+				letters++
+			} else {
+				break
+			}
+			fullWidth = fullWidth || folded != r
+			i += size
+		}
+		switch {
+		case digits > 0 && digits <= 9:
+			typ = orderedList
+		case letters > 0 && letters <= maxMarkerLen:
+			typ = orderedListFancy
+		default:
+			return ret, notList
+		}
+	}
+
+	// The delimiter must follow the marker directly
+	if i >= l {
+		return ret, notList
+	}
+	r, size = utf8.DecodeRune(line[i:])
+	switch r {
+	case fullWidthCloseParen, fullWidthPeriod:
+		fullWidth = true
+	case ')', '.':
+	default:
+		return ret, notList
+	}
+	if enclosed && r != fullWidthCloseParen && r != ')' {
+		return ret, notList
+	}
+	if !fullWidth {
+		return ret, notList
+	}
+	i += size
+	ret[3] = i
+	return finishListItem(line, i, ret, typ)
+}
+
+// isASCIIMarker reports whether the marker matched in line is plain ASCII,
+// in which case match[3]-1 is its delimiter byte.
+func isASCIIMarker(line []byte, match [6]int) bool {
+	return line[match[2]] < utf8.RuneSelf && line[match[3]-1] < utf8.RuneSelf &&
+		ordinalSuffix(line, match) == nil
+}
+
+// markerText returns the marker of a matched list item line without its
+// delimiter. Full-width, Arabic-Indic, roman numeral and parenthesized
+// character markers are folded to their ASCII form, and ordinal suffixes are
+// dropped.
+func markerText(line []byte, match [6]int) []byte {
+	if isASCIIMarker(line, match) {
+		if line[match[3]-1] == '#' {
+			// A bare '#' marker has no delimiter
+			return line[match[3]-1 : match[3]]
+		}
+		if isDoubleDelimiter(line, match) {
+			return line[match[2] : match[3]-2]
+		}
+		return line[match[2] : match[3]-1]
+	}
+	var text []byte
+	for i := match[2]; i < match[3]; {
+		r, size := utf8.DecodeRune(line[i:])
+		i += size
+		switch r {
+		case fullWidthOpenParen:
+			continue
+		case fullWidthCloseParen, fullWidthPeriod, ')', '.':
+			return text
+		}
+		if isOrdinalSuffix(r) {
+			return text
+		}
+		if isUnicodeRoman(r) {
+			text = foldUnicodeRoman(text, r)
+			continue
+		}
+		if isParenthesized(r) {
+			return foldParenthesized(text, r)
+		}
+		text = append(text, byte(foldArabicIndic(foldFullWidth(r))))
+	}
+	return text
+}
+
+// markerDelimiter returns the ASCII delimiter ('.' or ')') of a matched list
+// item line, folding full-width delimiters. Ordinal markers without a
+// delimiter, such as "1º", and double delimiters ("1.)") count as '.', and
+// parenthesized characters such as "⑴" as ')'. Configured bullets such as
+// "•" count as bulletMarker.
+func markerDelimiter(line []byte, match [6]int) byte {
+	if isDoubleDelimiter(line, match) {
+		return '.'
+	}
+	if markerBullet(line, match) != nil {
+		return bulletMarker
+	}
+	if isASCIIMarker(line, match) {
+		return line[match[3]-1]
+	}
+	r, _ := utf8.DecodeLastRune(line[:match[3]])
+	if r == fullWidthCloseParen || r == ')' || isParenthesized(r) {
+		return ')'
+	}
+	return '.'
+}
+
+// fullWidthDelimiter returns the original delimiter of a full-width marker,
+// including an opening parenthesis ("（）"), of a double delimiter (".)"),
+// or "()" for parenthesized characters such as "⑴". It returns nil for
+// single ASCII delimiters.
+func fullWidthDelimiter(line []byte, match [6]int) []byte {
+	if isDoubleDelimiter(line, match) {
+		return []byte(".)")
+	}
+	if isASCIIMarker(line, match) || ordinalSuffix(line, match) != nil || markerBullet(line, match) != nil {
+		return nil
+	}
+	delim := make([]byte, 0, 6)
+	if r, size := utf8.DecodeRune(line[match[2]:]); isParenthesized(r) {
+		return append(delim, "()"...)
+	} else if r == fullWidthOpenParen {
+		delim = append(delim, line[match[2]:match[2]+size]...)
+	}
+	_, size := utf8.DecodeLastRune(line[:match[3]])
+	if len(delim) == 0 && size == 1 {
+		// Arabic-Indic digits with an ASCII delimiter
+		return nil
+	}
+	return append(delim, line[match[3]-size:match[3]]...)
+}
+
+// markerWidth returns the column just after the marker of a matched list item
+// line. Full-width characters count as one column each, so continuation
+// lines are indented by characters rather than by UTF-8 bytes.
+func markerWidth(line []byte, match [6]int) int {
+	if isASCIIMarker(line, match) {
+		return match[3]
+	}
+	return match[2] + utf8.RuneCount(line[match[2]:match[3]])
+}
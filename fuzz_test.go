@@ -0,0 +1,100 @@
+package fancylists
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/yuin/goldmark"
+)
+
+// Seeds shared by the fuzz targets: markers of every family, and the edge
+// cases that once panicked or sliced out of range.
+var fuzzSeeds = []string{
+	"a. item", "iv) item", "#5. item", "#c) item", "1.) item", "1º item", "Step 1. item",
+	"§ 2. item", "Article IV. item", "（ｂ） item", "١. item", "Ⅻ. item", "⑴ item", "• item",
+	"a.", "a)", "#", "#.", ".", "", " ", "   ", "\n", "a.\n", "1.\t", "1º", "（", "⑴",
+	"MMMMI. item", "zzzzzz. item", "- [ ] task", "a. {.x}",
+}
+
+// fuzzPolicies selects the stricter policies, under which the list and item
+// parsers reject more markers and must still agree on every line.
+var fuzzPolicies = []Option{
+	WithMaxStart(30, StartLimitReject), WithLargeRoman(LargeRomanReject), WithOffsets(OffsetsLenient),
+	WithMixedCase(MixedCaseReject), WithTypes(LowerAlpha, UpperRoman), WithAlphabet("abcdefghjk"),
+	WithHashDepthTypes(), WithParagraphInterrupt(InterruptAny), WithLazyContinuation(LazyIndented),
+	WithDoubleBlankLineEnd(), WithHangingIndent(2), WithAlphaNumbering(AlphaPositional),
+}
+
+// fuzzOptions enables every marker syntax the options provide.
+var fuzzOptions = []Option{
+	WithFullWidthMarkers(), WithArabicIndicDigits(), WithOrdinalMarkers(), WithDoubleDelimiters(),
+	WithStepMarkers(), WithLegalMarkers(), WithUnicodeRoman(), WithParenthesizedMarkers(),
+	WithBullets("•◦"), WithDashBullets(), WithBareHashMarkers(), WithRelaxedIndent(),
+	WithAbbreviations(),
+}
+
+func FuzzParseListItem(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add([]byte(seed))
+	}
+	e := NewFancyLists(fuzzOptions...)
+	f.Fuzz(func(t *testing.T, line []byte) {
+		for _, strict := range []bool{true, false} {
+			m, typ := e.matchListItem(line, strict)
+			if typ == notList {
+				continue
+			}
+			if m[1] < 0 || m[1] > m[2] || m[2] >= m[3] || m[3] > len(line) {
+				t.Fatalf("matchListItem(%q) = %v, out of range", line, m)
+			}
+			if m[4] >= 0 && (m[4] < m[3] || m[4] > m[5] || m[5] > len(line)) {
+				t.Fatalf("matchListItem(%q) content = %v, out of range", line, m)
+			}
+			_ = markerText(line, m)
+			_ = markerDelimiter(line, m)
+			_ = markerWidth(line, m)
+			_ = fullWidthDelimiter(line, m)
+			_ = calcListOffset(line, m)
+		}
+	})
+}
+
+func FuzzMarkerConversion(f *testing.F) {
+	for _, seed := range []string{"a", "z", "aa", "ba", "zzzzzz", "i", "iv", "MMMMI", "ivx", "", "A1"} {
+		f.Add(seed)
+	}
+	positional := NewFancyLists(WithAlphaNumbering(AlphaPositional))
+	f.Fuzz(func(t *testing.T, marker string) {
+		_, _ = romanToNumber([]byte(marker))
+		_, _ = anyRomanToNumber([]byte(marker))
+		if v := alphabeticToNumber([]byte(marker)); v > 0 {
+			if label := formatLabel(v, "a", 0, latinAlphabet, AlphaBijective); !bytes.EqualFold(label, []byte(marker)) {
+				t.Errorf("alphabeticToNumber(%q) = %d, labelled %q", marker, v, label)
+			}
+		}
+		if v := positional.alphabetValue([]byte(marker)); v > 0 && marker[0]|0x20 != 'a' {
+			if label := positional.label(v, "a", 0); !bytes.EqualFold(label, []byte(marker)) {
+				t.Errorf("positional value of %q = %d, labelled %q", marker, v, label)
+			}
+		}
+	})
+}
+
+func FuzzConvert(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed + "\n" + seed + "\n\n  " + seed)
+	}
+	mds := []goldmark.Markdown{
+		mdBasic,
+		goldmark.New(goldmark.WithExtensions(NewFancyLists(fuzzOptions...))),
+		goldmark.New(goldmark.WithExtensions(NewFancyLists(append(fuzzOptions[:len(fuzzOptions):len(fuzzOptions)], fuzzPolicies...)...))),
+	}
+	f.Fuzz(func(t *testing.T, source string) {
+		for _, md := range mds {
+			var out bytes.Buffer
+			if err := md.Convert([]byte(source), &out); err != nil {
+				t.Fatalf("Convert(%q) error: %v", source, err)
+			}
+		}
+	})
+}
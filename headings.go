@@ -0,0 +1,59 @@
+package fancylists
+
+import (
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+// defaultHeadingTypes is the numbering WithHeadingNumbers uses when given no
+// types: 1, 1.1, 1.1.a, 1.1.a.i.
+var defaultHeadingTypes = []MarkerType{Numeric, Numeric, LowerAlpha, LowerRoman}
+
+// attrNameNumber is the attribute HeadingNumbersAttribute sets on headings.
+var attrNameNumber = []byte("data-number")
+
+// headingNumberTransformer numbers the headings of a document (see
+// WithHeadingNumbers).
+type headingNumberTransformer struct {
+	options FancyListsOptions
+}
+
+func (t *headingNumberTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	types := t.options.HeadingTypes
+	if len(types) == 0 {
+		// Options set through the struct fields may leave the types out
+		types = defaultHeadingTypes
+	}
+	e := &FancyListsOptions{HashDepthTypes: types, Alphabet: t.options.Alphabet, AlphaNumbering: t.options.AlphaNumbering}
+	var levels, counters []int
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := n.(*ast.Heading)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+		for len(levels) > 1 && heading.Level < levels[len(levels)-1] {
+			levels, counters = levels[:len(levels)-1], counters[:len(counters)-1]
+		}
+		if len(levels) == 0 || heading.Level > levels[len(levels)-1] {
+			levels, counters = append(levels, heading.Level), append(counters, 0)
+		}
+		counters[len(counters)-1]++
+
+		var label []byte
+		for depth, value := range counters {
+			if depth > 0 {
+				label = append(label, '.')
+			}
+			label = append(label, e.label(value, e.hashDepthType(depth), 0)...)
+		}
+		if t.options.HeadingNumbers == HeadingNumbersAttribute {
+			heading.SetAttribute(attrNameNumber, label)
+		} else if first := heading.FirstChild(); first != nil {
+			heading.InsertBefore(heading, first, ast.NewString(append(label, ' ')))
+		} else {
+			heading.AppendChild(heading, ast.NewString(label))
+		}
+		return ast.WalkSkipChildren, nil
+	})
+}
@@ -0,0 +1,42 @@
+package fancylists
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/yuin/goldmark"
+)
+
+// TestHeadingNumbersStructOptions configures heading numbers through the
+// struct fields rather than WithHeadingNumbers.
+func TestHeadingNumbersStructOptions(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		options *FancyListsOptions
+		source  string
+		want    string
+	}{
+		{
+			"default types",
+			&FancyListsOptions{HeadingNumbers: HeadingNumbersText},
+			"# Part\n\n## Section\n\n### Topic\n",
+			"<h1>1 Part</h1>\n<h2>1.1 Section</h2>\n<h3>1.1.a Topic</h3>\n",
+		},
+		{
+			"positional alphabet",
+			&FancyListsOptions{HeadingNumbers: HeadingNumbersAttribute, HeadingTypes: []MarkerType{LowerAlpha}, AlphaNumbering: AlphaPositional},
+			strings.Repeat("# Part\n", 27),
+			`<h1 data-number="ba">Part</h1>` + "\n",
+		},
+	} {
+		md := goldmark.New(goldmark.WithExtensions(tc.options))
+		var buf bytes.Buffer
+		if err := md.Convert([]byte(tc.source), &buf); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasSuffix(buf.String(), tc.want) {
+			t.Errorf("%s: got:\n%s\nwant it to end with:\n%s", tc.name, buf.String(), tc.want)
+		}
+	}
+}
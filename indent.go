@@ -0,0 +1,51 @@
+package fancylists
+
+import (
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+// defaultIndent is the indentation of WithIndentedOutput when none is given.
+const defaultIndent = "  "
+
+// writeIndent writes the indentation of a line starting with n's tag: one
+// Indent for each list and list item n is nested in.
+func (e *FancyListsOptions) writeIndent(w util.BufWriter, n ast.Node) {
+	if e.Indent == "" || e.Compact {
+		return
+	}
+	for p := n.Parent(); p != nil; p = p.Parent() {
+		if k := p.Kind(); k == ast.KindList || k == ast.KindListItem {
+			_, _ = w.WriteString(e.Indent)
+		}
+	}
+}
+
+// closesOnNewLine reports whether the closing tag of item starts a line:
+// its content ends with a block written on lines of its own, or it is an
+// empty item written with a newline between its tags.
+func (r *fancyListItemHTMLRenderer) closesOnNewLine(item ast.Node, list *ast.List) bool {
+	if last := item.LastChild(); last != nil {
+		return last.Kind() != ast.KindTextBlock
+	}
+	return r.options.EmptyItems == EmptyItemsNewline && r.emptyItem(item, list)
+}
+
+// indentedParagraphHTMLRenderer renders paragraphs as Goldmark's core
+// renderer does, indenting those of list items for WithIndentedOutput.
+type indentedParagraphHTMLRenderer struct {
+	options FancyListsOptions
+}
+
+func (r *indentedParagraphHTMLRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindParagraph, r.renderParagraph)
+}
+
+func (r *indentedParagraphHTMLRenderer) renderParagraph(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering && n.Parent() != nil && n.Parent().Kind() == ast.KindListItem {
+		r.options.writeIndent(w, n)
+	}
+	writeHTMLParagraph(w, n, entering)
+	return ast.WalkContinue, nil
+}
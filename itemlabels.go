@@ -0,0 +1,25 @@
+package fancylists
+
+import (
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/util"
+)
+
+// labeledList reports whether the items of list carry a data-label
+// attribute. Plain lists left to the core rendering, and minimal and
+// semantic output, never do.
+func (e *FancyListsOptions) labeledList(list *ast.List) bool {
+	return e.ItemLabels && !e.bareOutput() && list.IsOrdered() && !(isPlainList(list) && e.corePlainList(list))
+}
+
+// writeItemLabel writes the data-label attribute of item: its value
+// formatted as the list numbers it ("iv", "c", "07"), without the list's
+// prefix and delimiter. Items this extension did not parse get none.
+func (e *FancyListsOptions) writeItemLabel(w util.BufWriter, item ast.Node, list *ast.List) {
+	if _, ok := item.Attribute(attrNameValue); !ok {
+		return
+	}
+	_, _ = w.WriteString(` data-label="`)
+	_, _ = w.Write(util.EscapeHTML(e.label(itemValue(item), listType(list), listPadding(list))))
+	_ = w.WriteByte('"')
+}
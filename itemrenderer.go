@@ -0,0 +1,26 @@
+package fancylists
+
+import (
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/util"
+)
+
+// ListItemRenderer writes a list item in place of the extension's <li> tags
+// (see WithListItemRenderer). It is called when entering the item, before
+// its children are rendered, and again when leaving it. An error stops
+// rendering and is returned by Convert.
+type ListItemRenderer func(w util.BufWriter, item *ast.ListItem, entering bool) error
+
+// ItemValue returns the number the extension computed for an item of an
+// ordered list, for use in a ListItemRenderer. It returns false for bullet
+// items and other nodes.
+func ItemValue(item ast.Node) (int, bool) {
+	if _, ok := item.(*ast.ListItem); !ok {
+		return 0, false
+	}
+	if v, ok := item.Attribute(attrNameValue); ok {
+		value, ok := v.(int)
+		return value, ok
+	}
+	return 0, false
+}
@@ -0,0 +1,64 @@
+package fancylists
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/util"
+)
+
+func TestListItemRenderer(t *testing.T) {
+	render := func(w util.BufWriter, item *ast.ListItem, entering bool) error {
+		if !entering {
+			_, _ = w.WriteString("</div></li>\n")
+			return nil
+		}
+		_, _ = w.WriteString("<li>")
+		if value, ok := ItemValue(item); ok {
+			_, _ = w.WriteString(`<span class="num">` + strconv.Itoa(value) + "</span>")
+		}
+		_, _ = w.WriteString(`<div class="body">`)
+		return nil
+	}
+	md := goldmark.New(goldmark.WithExtensions(NewFancyLists(WithListItemRenderer(render))))
+	var buf bytes.Buffer
+	if err := md.Convert([]byte("c. One\nd. Two\n\n- Bullet\n"), &buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `<ol class="fancy fl-lcalpha" type="a" start="3">
+<li><span class="num">3</span><div class="body">One</div></li>
+<li><span class="num">4</span><div class="body">Two</div></li>
+</ol>
+<ul>
+<li><div class="body">Bullet</div></li>
+</ul>
+`
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestListItemRendererError(t *testing.T) {
+	errItem := errors.New("item rejected")
+	md := goldmark.New(goldmark.WithExtensions(NewFancyLists(WithListItemRenderer(
+		func(w util.BufWriter, item *ast.ListItem, entering bool) error {
+			return errItem
+		}))))
+	var buf bytes.Buffer
+	if err := md.Convert([]byte("a. One\n"), &buf); !errors.Is(err, errItem) {
+		t.Errorf("Convert error = %v; want %v", err, errItem)
+	}
+}
+
+func TestItemValue(t *testing.T) {
+	if _, ok := ItemValue(ast.NewParagraph()); ok {
+		t.Errorf("ItemValue of a paragraph reports a value")
+	}
+	if _, ok := ItemValue(ast.NewListItem(2)); ok {
+		t.Errorf("ItemValue of a bullet item reports a value")
+	}
+}
@@ -0,0 +1,103 @@
+package fancylists
+
+import (
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+// jatsListTypes maps list type attribute values to JATS list-type values.
+var jatsListTypes = map[string]string{
+	"1": "order",
+	"a": "alpha-lower",
+	"A": "alpha-upper",
+	"i": "roman-lower",
+	"I": "roman-upper",
+}
+
+// JATSRenderer renders lists as JATS <list> elements, for journals
+// converting Markdown manuscripts. Like DocBookRenderer it renders only
+// lists, list items and the text blocks of tight items, and is registered
+// ahead of the renderer that writes the rest of the document. The
+// paragraphs of loose items are <p> in both vocabularies, but inline
+// content such as emphasis, code spans and links stays HTML (<em>, <code>,
+// <a>), so documents using it need a further conversion to be valid JATS.
+type JATSRenderer struct {
+	options FancyListsOptions
+}
+
+// NewJATSRenderer returns a JATSRenderer. Of the options, Compact and
+// ItemValues affect its output.
+func NewJATSRenderer(opts ...Option) *JATSRenderer {
+	return &JATSRenderer{options: *NewFancyLists(opts...)}
+}
+
+// RegisterFuncs implements renderer.NodeRenderer.
+func (r *JATSRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindList, r.renderList)
+	reg.Register(ast.KindListItem, r.renderListItem)
+	reg.Register(ast.KindTextBlock, r.renderTextBlock)
+}
+
+// renderList writes a <list> with the list-type of the list's markers.
+func (r *JATSRenderer) renderList(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	n := node.(*ast.List)
+	if entering {
+		typ := "bullet"
+		if n.IsOrdered() {
+			typ = jatsListTypes[listType(n)]
+		}
+		_, _ = w.WriteString(`<list list-type="`)
+		_, _ = w.WriteString(typ)
+		_, _ = w.WriteString(`">`)
+	} else {
+		_, _ = w.WriteString("</list>")
+	}
+	if !r.options.Compact {
+		_ = w.WriteByte('\n')
+	}
+	return ast.WalkContinue, nil
+}
+
+// renderListItem writes a <list-item>. JATS lists have no start value, so
+// items of lists that do not count up from 1, explicitly numbered items and,
+// when ItemValues is set, every ordered item begin with a <label> holding
+// their marker.
+func (r *JATSRenderer) renderListItem(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		_, _ = w.WriteString("<list-item>")
+		if !r.options.Compact {
+			_ = w.WriteByte('\n')
+		}
+		list, _ := n.Parent().(*ast.List)
+		_, explicit := n.Attribute(attrNameExplicit)
+		if list != nil && list.IsOrdered() && (r.options.ItemValues || explicit || !countsFromOne(list)) {
+			_, _ = w.WriteString("<label>")
+			_, _ = w.Write(util.EscapeHTML(r.options.formatMarker(itemValue(n), listType(list), listPadding(list), list.Marker)))
+			_, _ = w.WriteString("</label>")
+			if !r.options.Compact {
+				_ = w.WriteByte('\n')
+			}
+		}
+	} else {
+		_, _ = w.WriteString("</list-item>")
+		if !r.options.Compact {
+			_ = w.WriteByte('\n')
+		}
+	}
+	return ast.WalkContinue, nil
+}
+
+// renderTextBlock wraps the text of tight list items in <p>, since a JATS
+// <list-item> holds only block elements.
+func (r *JATSRenderer) renderTextBlock(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		_, _ = w.WriteString("<p>")
+	} else {
+		_, _ = w.WriteString("</p>")
+		if !r.options.Compact {
+			_ = w.WriteByte('\n')
+		}
+	}
+	return ast.WalkContinue, nil
+}
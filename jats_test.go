@@ -0,0 +1,73 @@
+package fancylists
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+func TestJATSRenderer(t *testing.T) {
+	md := goldmark.New(
+		goldmark.WithExtensions(NewFancyLists()),
+		goldmark.WithRendererOptions(renderer.WithNodeRenderers(
+			util.Prioritized(NewJATSRenderer(), 100),
+		)),
+	)
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{"a. One\nb. Two\n", `<list list-type="alpha-lower">
+<list-item>
+<p>One</p>
+</list-item>
+<list-item>
+<p>Two</p>
+</list-item>
+</list>
+`},
+		{"C) Three\n   - Bullet\n", `<list list-type="alpha-upper">
+<list-item>
+<label>C)</label>
+<p>Three</p>
+<list list-type="bullet">
+<list-item>
+<p>Bullet</p>
+</list-item>
+</list>
+</list-item>
+</list>
+`},
+		{"1. One\n#5. Five\n", `<list list-type="order">
+<list-item>
+<p>One</p>
+</list-item>
+<list-item>
+<label>5.</label>
+<p>Five</p>
+</list-item>
+</list>
+`},
+		{"i. One\n\nii. Two\n", `<list list-type="roman-lower">
+<list-item>
+<p>One</p>
+</list-item>
+<list-item>
+<p>Two</p>
+</list-item>
+</list>
+`},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		if err := md.Convert([]byte(tt.source), &out); err != nil {
+			t.Fatal(err)
+		}
+		if out.String() != tt.want {
+			t.Errorf("%q: got:\n%s\nwant:\n%s", tt.source, out.String(), tt.want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package fancylists
+
+import (
+	"github.com/yuin/goldmark/ast"
+)
+
+// ItemList is a schema.org ItemList describing an ordered list, ready to be
+// marshaled with encoding/json into a JSON-LD script block.
+type ItemList struct {
+	Context         string     `json:"@context"`
+	Type            string     `json:"@type"`
+	Name            string     `json:"name,omitempty"`
+	ItemListOrder   string     `json:"itemListOrder"`
+	NumberOfItems   int        `json:"numberOfItems"`
+	ItemListElement []ListItem `json:"itemListElement"`
+}
+
+// ListItem is a schema.org ListItem, one element of an ItemList.
+type ListItem struct {
+	Type     string `json:"@type"`
+	Position int    `json:"position"`
+	Name     string `json:"name"`
+}
+
+// Schema.org item list orders.
+const (
+	schemaAscending  = "https://schema.org/ItemListOrderAscending"
+	schemaDescending = "https://schema.org/ItemListOrderDescending"
+)
+
+// ItemLists returns a schema.org ItemList for each ordered list of a parsed
+// document, in document order, for SEO pipelines emitting JSON-LD. A list is
+// named after the nearest heading before it, item positions are the
+// computed item values, and item names are the plain text of each item's
+// first block. Reversed lists are listed in descending order.
+func ItemLists(doc ast.Node, source []byte) []ItemList {
+	var lists []ItemList
+	var heading ast.Node
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n := n.(type) {
+		case *ast.Heading:
+			heading = n
+			return ast.WalkSkipChildren, nil
+		case *ast.List:
+			if n.IsOrdered() {
+				lists = append(lists, itemList(n, heading, source))
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+	return lists
+}
+
+// itemList describes list, named after heading if it is not nil.
+func itemList(list *ast.List, heading ast.Node, source []byte) ItemList {
+	l := ItemList{
+		Context:         "https://schema.org",
+		Type:            "ItemList",
+		ItemListOrder:   schemaAscending,
+		ItemListElement: []ListItem{},
+	}
+	if heading != nil {
+		l.Name = string(plainText(heading, source))
+	}
+	if isReversed(list) {
+		l.ItemListOrder = schemaDescending
+	}
+	position := list.Start
+	for item := list.FirstChild(); item != nil; item = item.NextSibling() {
+		if _, ok := item.Attribute(attrNameValue); ok {
+			position = itemValue(item)
+		}
+		var name string
+		if block := item.FirstChild(); block != nil && block.Kind() != ast.KindList {
+			name = string(plainText(block, source))
+		}
+		l.ItemListElement = append(l.ItemListElement, ListItem{Type: "ListItem", Position: position, Name: name})
+		position++
+	}
+	l.NumberOfItems = len(l.ItemListElement)
+	return l
+}
@@ -0,0 +1,38 @@
+package fancylists
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/text"
+)
+
+func TestItemLists(t *testing.T) {
+	md := goldmark.New(goldmark.WithExtensions(NewFancyLists(WithReversedLists())))
+	source := []byte(`## Making *tea*
+
+c. Boil water
+d. Steep
+   - Bullets are skipped
+
+## Countdown
+
+3. Three
+2. Two
+`)
+	lists := ItemLists(md.Parser().Parse(text.NewReader(source)), source)
+	got, err := json.Marshal(lists)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"@context":"https://schema.org","@type":"ItemList","name":"Making tea",` +
+		`"itemListOrder":"https://schema.org/ItemListOrderAscending","numberOfItems":2,"itemListElement":[` +
+		`{"@type":"ListItem","position":3,"name":"Boil water"},{"@type":"ListItem","position":4,"name":"Steep"}]},` +
+		`{"@context":"https://schema.org","@type":"ItemList","name":"Countdown",` +
+		`"itemListOrder":"https://schema.org/ItemListOrderDescending","numberOfItems":2,"itemListElement":[` +
+		`{"@type":"ListItem","position":3,"name":"Three"},{"@type":"ListItem","position":2,"name":"Two"}]}]`
+	if string(got) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
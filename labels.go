@@ -0,0 +1,69 @@
+package fancylists
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/brandenc40/romannumeral"
+)
+
+// latinAlphabet numbers alphabetic markers when no Alphabet is configured.
+const latinAlphabet = "abcdefghijklmnopqrstuvwxyz"
+
+// FormatLabel returns the label of the nth item of a list of type typ,
+// without a delimiter: FormatLabel(LowerRoman, 4) is "iv" and
+// FormatLabel(LowerAlpha, 28) is "ab". Alphabetic labels use the letters a-z,
+// and values that have no label of the type, such as roman numerals above
+// 3999, are written as numbers, as are all values when typ is not a single
+// marker family. Renderers, exporters and templates share it so labels
+// always match the rendered lists.
+func FormatLabel(typ MarkerType, n int) string {
+	for value, family := range markerTypes {
+		if family == typ {
+			return string(formatLabel(n, value, 0, latinAlphabet, AlphaBijective))
+		}
+	}
+	return string(formatLabel(n, "1", 0, latinAlphabet, AlphaBijective))
+}
+
+// formatLabel writes value as a label of type typ ("1", "a", "A", "i" or
+// "I") numbered with the letters of alphabet in the given numbering,
+// zero-padding numbers to width digits.
+func formatLabel(value int, typ string, width int, alphabet string, numbering AlphaNumbering) []byte {
+	var marker []byte
+	switch typ {
+	case "a", "A":
+		if numbering == AlphaPositional {
+			for v := value - 1; v >= 0; v /= len(alphabet) {
+				marker = append(marker, alphabet[v%len(alphabet)]|0x20)
+				if v < len(alphabet) {
+					break
+				}
+			}
+		} else {
+			for v := value; v > 0; v = (v - 1) / len(alphabet) {
+				marker = append(marker, alphabet[(v-1)%len(alphabet)]|0x20)
+			}
+		}
+		for i, j := 0, len(marker)-1; i < j; i, j = i+1, j-1 {
+			marker[i], marker[j] = marker[j], marker[i]
+		}
+		if typ == "A" {
+			marker = bytes.ToUpper(marker)
+		}
+	case "i", "I":
+		if roman, err := romannumeral.IntToBytes(value); err == nil {
+			marker = roman
+			if typ == "i" {
+				marker = bytes.ToLower(marker)
+			}
+		}
+	}
+	if marker == nil {
+		marker = strconv.AppendInt(nil, int64(value), 10)
+		for len(marker) < width {
+			marker = append([]byte{'0'}, marker...)
+		}
+	}
+	return marker
+}
@@ -0,0 +1,26 @@
+package fancylists
+
+import "testing"
+
+func TestFormatLabel(t *testing.T) {
+	cases := []struct {
+		typ  MarkerType
+		n    int
+		want string
+	}{
+		{Numeric, 28, "28"},
+		{LowerAlpha, 1, "a"},
+		{LowerAlpha, 28, "ab"},
+		{UpperAlpha, 702, "ZZ"},
+		{LowerRoman, 4, "iv"},
+		{UpperRoman, 1994, "MCMXCIV"},
+		{UpperRoman, 4000, "4000"},
+		{LowerAlpha, 0, "0"},
+		{LowerAlpha | UpperAlpha, 3, "3"},
+	}
+	for _, c := range cases {
+		if got := FormatLabel(c.typ, c.n); got != c.want {
+			t.Errorf("FormatLabel(%v, %d) = %q; want %q", c.typ, c.n, got, c.want)
+		}
+	}
+}
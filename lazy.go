@@ -0,0 +1,46 @@
+package fancylists
+
+import (
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+// lazyLineKey holds the line number of an unindented line that may not
+// continue the paragraph of the list item above it.
+var lazyLineKey = parser.NewContextKey()
+
+// refuseLazyLine marks the reader's current line, which ends a list, as not
+// continuing the paragraph of its last item when LazyIndented is set.
+func (e *FancyListsOptions) refuseLazyLine(reader text.Reader, pc parser.Context) {
+	if e.LazyContinuation == LazyIndented {
+		line, _ := reader.Position()
+		pc.Set(lazyLineKey, line)
+	}
+}
+
+// lazyBreakParser starts a new paragraph on a line marked by refuseLazyLine.
+// Goldmark appends any line no other block claims to the open paragraph, so
+// the only way to refuse a lazy continuation line is to open a block for
+// it; this parser may interrupt paragraphs, which Goldmark's own paragraph
+// parser may not, and otherwise behaves exactly like it.
+type lazyBreakParser struct {
+	parser.BlockParser
+}
+
+func (b *lazyBreakParser) Trigger() []byte {
+	return nil
+}
+
+func (b *lazyBreakParser) Open(parent ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	line, _ := reader.Position()
+	if v, ok := pc.Get(lazyLineKey).(int); !ok || v != line {
+		return nil, parser.NoChildren
+	}
+	pc.Set(lazyLineKey, nil)
+	return b.BlockParser.Open(parent, reader, pc)
+}
+
+func (b *lazyBreakParser) CanInterruptParagraph() bool {
+	return true
+}
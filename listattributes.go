@@ -0,0 +1,154 @@
+package fancylists
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// listAttributesKey holds the *[]ast.Node of attribute blocks to remove once
+// parsing is done.
+var listAttributesKey = parser.NewContextKey()
+
+// kindListAttributes is the NodeKind of a "{.class key=val}" line consumed
+// by the built-in block attribute parser. These nodes are removed from the
+// document before rendering.
+var kindListAttributes = ast.NewNodeKind("ListAttributes")
+
+// listAttributes is the placeholder block for an attribute line that has
+// already been applied to the list above it.
+type listAttributes struct {
+	ast.BaseBlock
+}
+
+// Kind implements ast.Node.Kind.
+func (n *listAttributes) Kind() ast.NodeKind {
+	return kindListAttributes
+}
+
+// Dump implements ast.Node.Dump.
+func (n *listAttributes) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+// listAttributesParser applies a "{.class key=val}" line directly below a
+// list to that list, as well as the "{loose}" and "{tight}" keywords. Lines
+// anywhere else are left to other parsers, so goldmark-attributes keeps
+// handling them when it is also registered.
+type listAttributesParser struct{}
+
+func (b *listAttributesParser) Trigger() []byte {
+	return []byte{'{'}
+}
+
+func (b *listAttributesParser) Open(parent ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	list, ok := parent.LastChild().(*ast.List)
+	if !ok || !followsNonBlankLine(reader) {
+		return nil, parser.NoChildren
+	}
+	line, _ := reader.PeekLine()
+	switch keyword := bytes.TrimSpace(line); {
+	case bytes.Equal(keyword, []byte("{loose}")):
+		list.SetAttribute(attrNameSpacing, spacingLoose)
+		reader.AdvanceToEOL()
+	case bytes.Equal(keyword, []byte("{tight}")):
+		list.SetAttribute(attrNameSpacing, spacingTight)
+		reader.AdvanceToEOL()
+	default:
+		savedLine, savedPosition := reader.Position()
+		attrs, ok := parser.ParseAttributes(reader)
+		if ok {
+			// Nothing but spaces may follow the closing brace
+			rest, _ := reader.PeekLine()
+			ok = util.IsBlank(rest)
+		}
+		if !ok {
+			reader.SetPosition(savedLine, savedPosition)
+			return nil, parser.NoChildren
+		}
+		for _, attr := range attrs {
+			// Attributes already set on the list are kept, as goldmark-attributes does
+			if _, exists := list.Attribute(attr.Name); !exists {
+				list.SetAttribute(attr.Name, attr.Value)
+			}
+		}
+	}
+
+	node := &listAttributes{}
+	var nodes *[]ast.Node
+	if v := pc.Get(listAttributesKey); v != nil {
+		nodes = v.(*[]ast.Node)
+	} else {
+		nodes = &[]ast.Node{}
+		pc.Set(listAttributesKey, nodes)
+	}
+	*nodes = append(*nodes, node)
+	return node, parser.NoChildren
+}
+
+func (b *listAttributesParser) Continue(node ast.Node, reader text.Reader, pc parser.Context) parser.State {
+	return parser.Close
+}
+
+func (b *listAttributesParser) Close(node ast.Node, reader text.Reader, pc parser.Context) {
+}
+
+func (b *listAttributesParser) CanInterruptParagraph() bool {
+	return true
+}
+
+func (b *listAttributesParser) CanAcceptIndentedLine() bool {
+	return false
+}
+
+// followsNonBlankLine reports whether the line before the reader's current
+// line has any content, so an attribute line separated from the list by a
+// blank line is not applied to it.
+func followsNonBlankLine(reader text.Reader) bool {
+	source := reader.Source()
+	_, segment := reader.Position()
+	i := segment.Start - 1
+	for i >= 0 && source[i] != '\n' {
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+	for i--; i >= 0 && source[i] != '\n'; i-- {
+		if !util.IsSpace(source[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// listAttributesTransformer removes the attribute placeholders from the
+// document once their attributes have been applied.
+type listAttributesTransformer struct{}
+
+func (t *listAttributesTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	v := pc.Get(listAttributesKey)
+	if v == nil {
+		return
+	}
+	for _, node := range *v.(*[]ast.Node) {
+		if parent := node.Parent(); parent != nil {
+			parent.RemoveChild(parent, node)
+		}
+	}
+	pc.Set(listAttributesKey, nil)
+}
+
+// listAttributesHTMLRenderer renders nothing for attribute placeholders, in
+// case a document is rendered without running the transformer.
+type listAttributesHTMLRenderer struct{}
+
+func (r *listAttributesHTMLRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(kindListAttributes, func(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+		return ast.WalkSkipChildren, nil
+	})
+}
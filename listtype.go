@@ -0,0 +1,63 @@
+package fancylists
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+// The parser records the type computed from a list's first marker in the
+// internal fl-type attribute, leaving the public type attribute free for the
+// user. Once block attributes have been applied, listTypeTransformer copies
+// the computed type to the type attribute of every list the user did not
+// give one, so code walking the AST sees the same type the renderer writes.
+
+// addTypedList records a list with a computed type for listTypeTransformer.
+func addTypedList(pc parser.Context, list *ast.List) {
+	s := stateOf(pc)
+	s.typedLists = append(s.typedLists, list)
+}
+
+// listTypeTransformer sets the public type attribute of lists from their
+// computed type unless the user already set one.
+type listTypeTransformer struct{}
+
+func (t *listTypeTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	// Documents without list markers still count as converted by the
+	// extension.
+	s := stateOf(pc)
+	markCoreLists(doc, s)
+	for _, list := range s.typedLists {
+		if _, ok := list.AttributeString("type"); ok {
+			continue
+		}
+		if typ, ok := list.Attribute(attrNameListType); ok {
+			list.SetAttribute(attrNameType, typ)
+		}
+	}
+	s.typedLists = nil
+}
+
+// userType returns the type attribute of a list if the user set one that
+// differs from the computed type.
+func userType(n ast.Node) (interface{}, bool) {
+	v, ok := n.AttributeString("type")
+	if !ok {
+		return nil, false
+	}
+	var b []byte
+	switch t := v.(type) {
+	case []byte:
+		b = t
+	case string:
+		b = []byte(t)
+	default:
+		return nil, false
+	}
+	if computed, ok := n.Attribute(attrNameListType); ok && bytes.Equal(b, computed.([]byte)) {
+		return nil, false
+	}
+	return v, true
+}
@@ -0,0 +1,45 @@
+package fancylists
+
+import "github.com/yuin/goldmark/ast"
+
+// markedList reports whether the items of list are rendered with marker
+// spans. Plain lists left to the core rendering, and minimal and semantic
+// output, never are.
+func (e *FancyListsOptions) markedList(list *ast.List) bool {
+	return e.MarkerSpans != MarkerSpansOff && !e.bareOutput() && list.IsOrdered() && !(isPlainList(list) && e.corePlainList(list))
+}
+
+// itemMarker returns the marker text shown for item: the marker as written,
+// or for '#' markers and with WithRenumbering the marker the item's value
+// would have been written with, after the list's prefix. It returns nil for
+// items this extension did not parse.
+func (e *FancyListsOptions) itemMarker(item ast.Node, list *ast.List, source []byte) []byte {
+	segment, ok := MarkerSegment(item)
+	if !ok {
+		return nil
+	}
+	marker := segment.Value(source)
+	if len(marker) == 0 || marker[0] == '#' || e.Renumber {
+		marker = e.formatMarker(itemValue(item), listType(list), listPadding(list), list.Marker)
+	}
+	if prefix := listPrefix(list); prefix != nil {
+		// Prefixes such as "Step " are not part of the marker segment
+		marker = append(append([]byte(nil), prefix...), marker...)
+	}
+	return marker
+}
+
+// formatMarker writes value as a marker of type typ ("1", "a", "A", "i" or
+// "I"), zero-padded to width digits, followed by delimiter.
+func (e *FancyListsOptions) formatMarker(value int, typ string, width int, delimiter byte) []byte {
+	return append(e.label(value, typ, width), delimiter)
+}
+
+// label is formatLabel with the configured Alphabet.
+func (e *FancyListsOptions) label(value int, typ string, width int) []byte {
+	alphabet := e.Alphabet
+	if alphabet == "" {
+		alphabet = latinAlphabet
+	}
+	return formatLabel(value, typ, width, alphabet, e.AlphaNumbering)
+}
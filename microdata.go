@@ -0,0 +1,50 @@
+package fancylists
+
+import (
+	"strconv"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/util"
+)
+
+// Schema.org types written by WithMicrodata.
+const (
+	schemaItemList = "https://schema.org/ItemList"
+	schemaListItem = "https://schema.org/ListItem"
+)
+
+// microdataList reports whether list is annotated with schema.org
+// microdata. Minimal and semantic output and lists rendered exactly as the
+// core renderer would are not.
+func (e *FancyListsOptions) microdataList(list *ast.List) bool {
+	return e.Microdata && !e.bareOutput() && !(isPlainList(list) && e.corePlainList(list))
+}
+
+// writeListMicrodata writes the attributes making a list an ItemList.
+func writeListMicrodata(w util.BufWriter) {
+	_, _ = w.WriteString(` itemscope itemtype="` + schemaItemList + `"`)
+}
+
+// writeItemMicrodata writes the attributes making item an element of its
+// ItemList.
+func writeItemMicrodata(w util.BufWriter) {
+	_, _ = w.WriteString(` itemprop="itemListElement" itemscope itemtype="` + schemaListItem + `"`)
+}
+
+// writeItemPosition writes the <meta> element giving the position of item:
+// its value in ordered lists and its index, from 1, in bullet lists.
+func writeItemPosition(w util.BufWriter, item ast.Node, xhtml bool) {
+	position := itemValue(item)
+	if _, ordered := item.Attribute(attrNameValue); !ordered {
+		for c := item; c != nil; c = c.PreviousSibling() {
+			position++
+		}
+	}
+	_, _ = w.WriteString(`<meta itemprop="position" content="`)
+	_, _ = w.WriteString(strconv.Itoa(position))
+	if xhtml {
+		_, _ = w.WriteString(`" />`)
+	} else {
+		_, _ = w.WriteString(`">`)
+	}
+}
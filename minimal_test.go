@@ -0,0 +1,78 @@
+package fancylists
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// ugcAttributes is the part of bluemonday's UGC policy, the usual sanitizer
+// for user-written HTML, that covers the elements lists render to: the
+// attributes it keeps on each element and the values they may take. Other
+// elements and attributes are removed.
+var ugcAttributes = map[string]map[string]*regexp.Regexp{
+	"ol":   {"type": ugcListType, "dir": ugcDirection},
+	"ul":   {"type": ugcListType, "dir": ugcDirection},
+	"li":   {"type": ugcListType, "value": ugcInteger, "dir": ugcDirection},
+	"p":    {"dir": ugcDirection},
+	"span": {"dir": ugcDirection},
+}
+
+var (
+	ugcListType  = regexp.MustCompile(`(?i)^(circle|disc|square|a|A|i|I|1)$`)
+	ugcDirection = regexp.MustCompile(`(?i)^(rtl|ltr)$`)
+	ugcInteger   = regexp.MustCompile(`^[0-9]+$`)
+	htmlTag      = regexp.MustCompile(`<(/?)([a-zA-Z][a-zA-Z0-9]*)([^>]*)>`)
+	htmlAttr     = regexp.MustCompile(`([^\s=/]+)(?:="([^"]*)")?`)
+)
+
+// ugcRejects returns what the UGC policy would remove from html, or "" if
+// it would keep everything.
+func ugcRejects(html string) string {
+	var rejected []string
+	for _, tag := range htmlTag.FindAllStringSubmatch(html, -1) {
+		attrs, ok := ugcAttributes[tag[2]]
+		if !ok {
+			rejected = append(rejected, "<"+tag[2]+">")
+			continue
+		}
+		for _, attr := range htmlAttr.FindAllStringSubmatch(tag[3], -1) {
+			if valid, ok := attrs[attr[1]]; !ok || !valid.MatchString(attr[2]) {
+				rejected = append(rejected, tag[2]+" "+attr[0])
+			}
+		}
+	}
+	return strings.Join(rejected, ", ")
+}
+
+func TestMinimalOutputSurvivesUGCPolicy(t *testing.T) {
+	md := CreateGoldmarkInstance(createOptions{
+		blockAttributes: true,
+		enableGFM:       true,
+		fancyOptions: []Option{
+			WithMinimalOutput(), WithReversedLists(), WithArabicIndicDigits(), WithPadding(PaddingClass),
+			WithChecklists(ChecklistReplace), WithBulletClasses(), WithMarkerSpans(MarkerSpansList),
+		},
+	})
+	for _, source := range []string{
+		"a. One\nb. Two\n",
+		"III. Three\n#. Four\n#9. Nine\n",
+		"c) Three\nb) Two\na) One\n",
+		"007. Seven\n008. Eight\n",
+		"١. One\n٢. Two\n",
+		"1. One\n   - Nested\n     i. Deeper\n",
+		"a. One\n{.steps data-x=\"1\" onclick=\"alert(1)\" style=\"color: red\"}\n",
+	} {
+		var out bytes.Buffer
+		if err := md.Convert([]byte(source), &out); err != nil {
+			t.Fatal(err)
+		}
+		if rejected := ugcRejects(out.String()); rejected != "" {
+			t.Errorf("the sanitizer would remove %s from the output of %q:\n%s", rejected, source, out.String())
+		}
+	}
+	if rejected := ugcRejects(`<ol class="fancy" start="3"><li value="x">One</li></ol>`); rejected != `ol class="fancy", ol start="3", li value="x"` {
+		t.Errorf("ugcRejects = %q", rejected)
+	}
+}
@@ -0,0 +1,277 @@
+package fancylists
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The option enumerations marshal to and from text by name, so options
+// written as JSON, as in the conformance corpus, do not depend on the
+// order of the constants. The names of each enumeration follow the order of
+// its constants.
+var (
+	startLimitNames      = []string{"reject", "clamp"}
+	paddingNames         = []string{"none", "class", "data"}
+	ambiguityNames       = []string{"context", "alphabetic", "roman"}
+	attributePolicyNames = []string{"merge", "user-wins", "extension-wins"}
+	checklistNames       = []string{"off", "accompany", "replace"}
+	offsetNames          = []string{"strict", "lenient"}
+	markerSpanNames      = []string{"off", "list", "ordered"}
+	headingNumberNames   = []string{"off", "text", "attribute"}
+	lazyNames            = []string{"commonmark", "indented"}
+	emptyItemNames       = []string{"inline", "newline", "self-closing"}
+	interruptNames       = []string{"from-one", "letters", "any"}
+	largeRomanNames      = []string{"accept", "alpha", "reject"}
+	alphaNumberingNames  = []string{"bijective", "positional"}
+	parserOrderNames     = []string{"first", "last"}
+	inlineStyleNames     = []string{"none", "with-classes", "only"}
+	mixedCaseNames       = []string{"normalize", "reject", "diagnose"}
+)
+
+// markerTypeNames lists the marker families in the order MarkerType's text
+// form writes them.
+var markerTypeNames = []string{"1", "a", "A", "i", "I"}
+
+// marshalName returns the name of value v of the enumeration kind.
+func marshalName(kind string, names []string, v int) ([]byte, error) {
+	if v < 0 || v >= len(names) {
+		return nil, fmt.Errorf("fancylists: invalid %s %d", kind, v)
+	}
+	return []byte(names[v]), nil
+}
+
+// unmarshalName returns the value of the enumeration kind named text.
+func unmarshalName(kind string, names []string, text []byte) (int, error) {
+	for v, name := range names {
+		if name == string(text) {
+			return v, nil
+		}
+	}
+	return 0, fmt.Errorf("fancylists: unknown %s %q (want one of %s)", kind, text, strings.Join(names, ", "))
+}
+
+// MarshalText writes the families of t joined by '|', such as "1|a|I".
+func (t MarkerType) MarshalText() ([]byte, error) {
+	var names []string
+	for _, name := range markerTypeNames {
+		if family := markerTypes[name]; t&family != 0 {
+			names = append(names, name)
+			t &^= family
+		}
+	}
+	if t != 0 {
+		return nil, fmt.Errorf("fancylists: invalid marker type %#x", uint(t))
+	}
+	return []byte(strings.Join(names, "|")), nil
+}
+
+// UnmarshalText reads the form MarshalText writes.
+func (t *MarkerType) UnmarshalText(text []byte) error {
+	*t = 0
+	if len(text) == 0 {
+		return nil
+	}
+	for _, name := range strings.Split(string(text), "|") {
+		family, ok := markerTypes[name]
+		if !ok {
+			return fmt.Errorf("fancylists: unknown marker type %q (want one of %s)", name, strings.Join(markerTypeNames, ", "))
+		}
+		*t |= family
+	}
+	return nil
+}
+
+// MarshalText writes the policy's name: "reject" or "clamp".
+func (p StartLimitPolicy) MarshalText() ([]byte, error) {
+	return marshalName("start limit policy", startLimitNames, int(p))
+}
+
+// UnmarshalText reads the form MarshalText writes.
+func (p *StartLimitPolicy) UnmarshalText(text []byte) error {
+	v, err := unmarshalName("start limit policy", startLimitNames, text)
+	*p = StartLimitPolicy(v)
+	return err
+}
+
+// MarshalText writes the mode's name: "none", "class" or "data".
+func (m PaddingMode) MarshalText() ([]byte, error) {
+	return marshalName("padding mode", paddingNames, int(m))
+}
+
+// UnmarshalText reads the form MarshalText writes.
+func (m *PaddingMode) UnmarshalText(text []byte) error {
+	v, err := unmarshalName("padding mode", paddingNames, text)
+	*m = PaddingMode(v)
+	return err
+}
+
+// MarshalText writes the policy's name: "context", "alphabetic" or "roman".
+func (p AmbiguityPolicy) MarshalText() ([]byte, error) {
+	return marshalName("ambiguity policy", ambiguityNames, int(p))
+}
+
+// UnmarshalText reads the form MarshalText writes.
+func (p *AmbiguityPolicy) UnmarshalText(text []byte) error {
+	v, err := unmarshalName("ambiguity policy", ambiguityNames, text)
+	*p = AmbiguityPolicy(v)
+	return err
+}
+
+// MarshalText writes the policy's name: "merge", "user-wins" or
+// "extension-wins".
+func (p AttributePolicy) MarshalText() ([]byte, error) {
+	return marshalName("attribute policy", attributePolicyNames, int(p))
+}
+
+// UnmarshalText reads the form MarshalText writes.
+func (p *AttributePolicy) UnmarshalText(text []byte) error {
+	v, err := unmarshalName("attribute policy", attributePolicyNames, text)
+	*p = AttributePolicy(v)
+	return err
+}
+
+// MarshalText writes the mode's name: "off", "accompany" or "replace".
+func (m ChecklistMode) MarshalText() ([]byte, error) {
+	return marshalName("checklist mode", checklistNames, int(m))
+}
+
+// UnmarshalText reads the form MarshalText writes.
+func (m *ChecklistMode) UnmarshalText(text []byte) error {
+	v, err := unmarshalName("checklist mode", checklistNames, text)
+	*m = ChecklistMode(v)
+	return err
+}
+
+// MarshalText writes the mode's name: "strict" or "lenient".
+func (m OffsetMode) MarshalText() ([]byte, error) {
+	return marshalName("offset mode", offsetNames, int(m))
+}
+
+// UnmarshalText reads the form MarshalText writes.
+func (m *OffsetMode) UnmarshalText(text []byte) error {
+	v, err := unmarshalName("offset mode", offsetNames, text)
+	*m = OffsetMode(v)
+	return err
+}
+
+// MarshalText writes the mode's name: "off", "list" or "ordered".
+func (m MarkerSpanMode) MarshalText() ([]byte, error) {
+	return marshalName("marker span mode", markerSpanNames, int(m))
+}
+
+// UnmarshalText reads the form MarshalText writes.
+func (m *MarkerSpanMode) UnmarshalText(text []byte) error {
+	v, err := unmarshalName("marker span mode", markerSpanNames, text)
+	*m = MarkerSpanMode(v)
+	return err
+}
+
+// MarshalText writes the mode's name: "off", "text" or "attribute".
+func (m HeadingNumberMode) MarshalText() ([]byte, error) {
+	return marshalName("heading number mode", headingNumberNames, int(m))
+}
+
+// UnmarshalText reads the form MarshalText writes.
+func (m *HeadingNumberMode) UnmarshalText(text []byte) error {
+	v, err := unmarshalName("heading number mode", headingNumberNames, text)
+	*m = HeadingNumberMode(v)
+	return err
+}
+
+// MarshalText writes the mode's name: "commonmark" or "indented".
+func (m LazyContinuationMode) MarshalText() ([]byte, error) {
+	return marshalName("lazy continuation mode", lazyNames, int(m))
+}
+
+// UnmarshalText reads the form MarshalText writes.
+func (m *LazyContinuationMode) UnmarshalText(text []byte) error {
+	v, err := unmarshalName("lazy continuation mode", lazyNames, text)
+	*m = LazyContinuationMode(v)
+	return err
+}
+
+// MarshalText writes the mode's name: "inline", "newline" or
+// "self-closing".
+func (m EmptyItemMode) MarshalText() ([]byte, error) {
+	return marshalName("empty item mode", emptyItemNames, int(m))
+}
+
+// UnmarshalText reads the form MarshalText writes.
+func (m *EmptyItemMode) UnmarshalText(text []byte) error {
+	v, err := unmarshalName("empty item mode", emptyItemNames, text)
+	*m = EmptyItemMode(v)
+	return err
+}
+
+// MarshalText writes the policy's name: "from-one", "letters" or "any".
+func (p InterruptPolicy) MarshalText() ([]byte, error) {
+	return marshalName("interrupt policy", interruptNames, int(p))
+}
+
+// UnmarshalText reads the form MarshalText writes.
+func (p *InterruptPolicy) UnmarshalText(text []byte) error {
+	v, err := unmarshalName("interrupt policy", interruptNames, text)
+	*p = InterruptPolicy(v)
+	return err
+}
+
+// MarshalText writes the policy's name: "accept", "alpha" or "reject".
+func (p LargeRomanPolicy) MarshalText() ([]byte, error) {
+	return marshalName("large roman policy", largeRomanNames, int(p))
+}
+
+// UnmarshalText reads the form MarshalText writes.
+func (p *LargeRomanPolicy) UnmarshalText(text []byte) error {
+	v, err := unmarshalName("large roman policy", largeRomanNames, text)
+	*p = LargeRomanPolicy(v)
+	return err
+}
+
+// MarshalText writes the numbering's name: "bijective" or "positional".
+func (n AlphaNumbering) MarshalText() ([]byte, error) {
+	return marshalName("alphabetic numbering", alphaNumberingNames, int(n))
+}
+
+// UnmarshalText reads the form MarshalText writes.
+func (n *AlphaNumbering) UnmarshalText(text []byte) error {
+	v, err := unmarshalName("alphabetic numbering", alphaNumberingNames, text)
+	*n = AlphaNumbering(v)
+	return err
+}
+
+// MarshalText writes the order's name: "first" or "last".
+func (o ParserOrder) MarshalText() ([]byte, error) {
+	return marshalName("parser order", parserOrderNames, int(o))
+}
+
+// UnmarshalText reads the form MarshalText writes.
+func (o *ParserOrder) UnmarshalText(text []byte) error {
+	v, err := unmarshalName("parser order", parserOrderNames, text)
+	*o = ParserOrder(v)
+	return err
+}
+
+// MarshalText writes the mode's name: "none", "with-classes" or "only".
+func (m InlineStyleMode) MarshalText() ([]byte, error) {
+	return marshalName("inline style mode", inlineStyleNames, int(m))
+}
+
+// UnmarshalText reads the form MarshalText writes.
+func (m *InlineStyleMode) UnmarshalText(text []byte) error {
+	v, err := unmarshalName("inline style mode", inlineStyleNames, text)
+	*m = InlineStyleMode(v)
+	return err
+}
+
+// MarshalText writes the policy's name: "normalize", "reject" or
+// "diagnose".
+func (p MixedCasePolicy) MarshalText() ([]byte, error) {
+	return marshalName("mixed-case policy", mixedCaseNames, int(p))
+}
+
+// UnmarshalText reads the form MarshalText writes.
+func (p *MixedCasePolicy) UnmarshalText(text []byte) error {
+	v, err := unmarshalName("mixed-case policy", mixedCaseNames, text)
+	*p = MixedCasePolicy(v)
+	return err
+}
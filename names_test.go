@@ -0,0 +1,40 @@
+package fancylists
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOptionsJSON(t *testing.T) {
+	e := NewFancyLists(
+		WithTypes(Numeric, LowerAlpha, UpperRoman),
+		WithMaxStart(100, StartLimitClamp),
+		WithPadding(PaddingDataAttribute),
+		WithEmptyItems(EmptyItemsSelfClosing),
+		WithLargeRoman(LargeRomanReject),
+	)
+	data, err := json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"Types":"1|a|I"`, `"MaxStartPolicy":"clamp"`, `"Padding":"data"`, `"EmptyItems":"self-closing"`, `"LargeRoman":"reject"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("JSON lacks %s:\n%s", want, data)
+		}
+	}
+	got := NewFancyLists()
+	if err := json.Unmarshal(data, got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, e) {
+		t.Errorf("round trip = %+v; want %+v", got, e)
+	}
+	if err := json.Unmarshal([]byte(`{"Padding":"wide"}`), got); err == nil || !strings.Contains(err.Error(), "unknown padding mode") {
+		t.Errorf("unknown name: error %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"Types":"1|b"}`), got); err == nil {
+		t.Error("unknown marker type accepted")
+	}
+}
@@ -0,0 +1,95 @@
+package fancylists
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+// WriteOPML writes the lists of a parsed document as an OPML 2.0 outline,
+// for outliners and mind-mapping tools. Every list item becomes an
+// <outline> whose text attribute holds the plain text of the item's first
+// block; items of ordered lists keep their marker ("iv.") in a label
+// attribute. Lists nested in an item become nested outlines. Other content
+// is left out.
+func WriteOPML(w io.Writer, doc ast.Node, source []byte, title string) error {
+	bw := bufio.NewWriter(w)
+	_, _ = bw.WriteString(xml.Header)
+	_, _ = bw.WriteString("<opml version=\"2.0\">\n<head>\n<title>")
+	_ = xml.EscapeText(bw, []byte(title))
+	_, _ = bw.WriteString("</title>\n</head>\n<body>\n")
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if list, ok := n.(*ast.List); ok && entering {
+			writeOPMLList(bw, list, source, 1)
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	_, _ = bw.WriteString("</body>\n</opml>\n")
+	return bw.Flush()
+}
+
+// writeOPMLList writes the items of list as outlines indented to depth.
+func writeOPMLList(w *bufio.Writer, list *ast.List, source []byte, depth int) {
+	var options FancyListsOptions
+	indent := strings.Repeat("  ", depth)
+	for item := list.FirstChild(); item != nil; item = item.NextSibling() {
+		_, _ = w.WriteString(indent)
+		_, _ = w.WriteString(`<outline text="`)
+		if block := item.FirstChild(); block != nil {
+			if _, ok := block.(*ast.List); !ok {
+				_ = xml.EscapeText(w, plainText(block, source))
+			}
+		}
+		_ = w.WriteByte('"')
+		if list.IsOrdered() {
+			if marker := options.itemMarker(item, list, source); marker != nil {
+				_, _ = w.WriteString(` label="`)
+				_ = xml.EscapeText(w, marker)
+				_ = w.WriteByte('"')
+			}
+		}
+		var nested []*ast.List
+		for c := item.FirstChild(); c != nil; c = c.NextSibling() {
+			if l, ok := c.(*ast.List); ok {
+				nested = append(nested, l)
+			}
+		}
+		if len(nested) == 0 {
+			_, _ = w.WriteString("/>\n")
+			continue
+		}
+		_, _ = w.WriteString(">\n")
+		for _, l := range nested {
+			writeOPMLList(w, l, source, depth+1)
+		}
+		_, _ = w.WriteString(indent)
+		_, _ = w.WriteString("</outline>\n")
+	}
+}
+
+// plainText returns the text of the inline content of block, with line
+// breaks replaced by spaces.
+func plainText(block ast.Node, source []byte) []byte {
+	var buf bytes.Buffer
+	_ = ast.Walk(block, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch t := n.(type) {
+		case *ast.Text:
+			buf.Write(t.Segment.Value(source))
+			if t.SoftLineBreak() || t.HardLineBreak() {
+				buf.WriteByte(' ')
+			}
+		case *ast.String:
+			buf.Write(t.Value)
+		}
+		return ast.WalkContinue, nil
+	})
+	return bytes.TrimSpace(buf.Bytes())
+}
@@ -0,0 +1,45 @@
+package fancylists
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/text"
+)
+
+func TestWriteOPML(t *testing.T) {
+	md := goldmark.New(goldmark.WithExtensions(NewFancyLists()))
+	source := []byte(`# Plan
+
+iii. Design *the* "API"
+     continued
+     - Sketch
+     - Review
+#. Build
+
+Closing text
+`)
+	doc := md.Parser().Parse(text.NewReader(source))
+	var out bytes.Buffer
+	if err := WriteOPML(&out, doc, source, "Plan & notes"); err != nil {
+		t.Fatal(err)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<opml version="2.0">
+<head>
+<title>Plan &amp; notes</title>
+</head>
+<body>
+  <outline text="Design the &#34;API&#34; continued" label="iii.">
+    <outline text="Sketch"/>
+    <outline text="Review"/>
+  </outline>
+  <outline text="Build" label="iv."/>
+</body>
+</opml>
+`
+	if out.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
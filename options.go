@@ -0,0 +1,1203 @@
+package fancylists
+
+import (
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// StartLimitPolicy selects how a computed start value above
+// FancyListsOptions.MaxStart is handled.
+type StartLimitPolicy int
+
+const (
+	// StartLimitReject treats markers whose start value exceeds the cap, and
+	// '#' markers whose value hint does ("#5000."), as ordinary text. The one
+	// exception is CommonMark's own numeric markers ("5000." or "5000)"):
+	// they are always lists, so their start value is clamped instead.
+	// Full-width, Arabic-Indic and prefixed numbers are rejected.
+	StartLimitReject StartLimitPolicy = iota
+	// StartLimitClamp keeps the list but lowers its start value to the cap.
+	StartLimitClamp
+)
+
+// PaddingMode selects how the width of zero-padded numeric markers such as
+// "003." is exposed in the rendered HTML.
+type PaddingMode int
+
+const (
+	// PaddingNone discards the padding; "003." renders as start="3".
+	PaddingNone PaddingMode = iota
+	// PaddingClass adds a ClassPaddingPrefix class with the marker width ("fl-pad-3").
+	PaddingClass
+	// PaddingDataAttribute adds a data-padding attribute with the marker width.
+	PaddingDataAttribute
+)
+
+// AmbiguityPolicy selects how standalone "i.", "v." and "x." markers (and
+// their uppercase forms), which are both letters and roman numerals, are read.
+type AmbiguityPolicy int
+
+const (
+	// AmbiguousContext uses the built-in heuristics: "i" starts a roman list
+	// unless it continues a same-case alphabetic list, "v" and "x" are letters.
+	AmbiguousContext AmbiguityPolicy = iota
+	// AmbiguousAlphabetic always reads them as letters (i=9, v=22, x=24).
+	AmbiguousAlphabetic
+	// AmbiguousRoman always reads them as roman numerals (i=1, v=5, x=10).
+	AmbiguousRoman
+)
+
+// AttributePolicy selects how class and type attributes supplied by the user,
+// for example with goldmark-attributes or WithBlockAttributes, combine with
+// the values the extension computes for an ordered list.
+type AttributePolicy int
+
+const (
+	// AttributesMerge writes the computed classes followed by the user's
+	// classes. A user type that HTML defines for ordered lists (1, a, A, i
+	// or I) replaces the computed type, and the type class follows it;
+	// other values are ignored.
+	AttributesMerge AttributePolicy = iota
+	// AttributesUserWins writes only the user's classes and type when they
+	// are set. The computed values are used when they are not.
+	AttributesUserWins
+	// AttributesExtensionWins writes only the computed classes and type,
+	// ignoring the user's class and type on ordered lists.
+	AttributesExtensionWins
+)
+
+// ChecklistMode selects how ordered lists of task items ("1. [ ] step") are
+// rendered.
+type ChecklistMode int
+
+const (
+	// ChecklistOff leaves task items to other extensions; with GFM enabled
+	// they render with a checkbox next to the number.
+	ChecklistOff ChecklistMode = iota
+	// ChecklistAccompany renders the checkbox next to the item's number.
+	ChecklistAccompany
+	// ChecklistReplace adds ClassChecklist to the list so the stylesheet
+	// hides the numbers, leaving only the checkboxes.
+	ChecklistReplace
+)
+
+// OffsetMode selects how the indentation of list lines inside block quotes
+// is measured.
+type OffsetMode int
+
+const (
+	// OffsetsStrict measures indentation after the block quote marker and
+	// its optional space, as CommonMark and Goldmark's core list parser do.
+	// ">a. one" followed by ">   b. two" makes b a sibling, because the
+	// second line's optional space is not counted.
+	OffsetsStrict OffsetMode = iota
+	// OffsetsLenient measures indentation in source columns, so list lines
+	// in a block quote nest the same way whether or not each '>' is followed
+	// by a space.
+	OffsetsLenient
+)
+
+// MarkerType is a family of ordered list markers. Types combine with '|' and
+// are enabled with WithTypes.
+type MarkerType uint
+
+const (
+	// Numeric markers: "1.", "2)", and their full-width and Arabic-Indic forms.
+	Numeric MarkerType = 1 << iota
+	// LowerAlpha markers: "a.", "b)".
+	LowerAlpha
+	// UpperAlpha markers: "A.", "B)".
+	UpperAlpha
+	// LowerRoman markers: "i.", "ii)".
+	LowerRoman
+	// UpperRoman markers: "I.", "II)".
+	UpperRoman
+)
+
+// markerTypes maps each list type value to its marker family.
+var markerTypes = map[string]MarkerType{
+	"1": Numeric,
+	"a": LowerAlpha,
+	"A": UpperAlpha,
+	"i": LowerRoman,
+	"I": UpperRoman,
+}
+
+// typeEnabled reports whether lists of type typ ("1", "a", "A", "i", "I")
+// may be opened. The empty string is numeric. All types are enabled unless
+// WithTypes was given.
+func (e *FancyListsOptions) typeEnabled(typ string) bool {
+	if typ == "" {
+		typ = "1"
+	}
+	return e.Types == 0 || e.Types&markerTypes[typ] != 0
+}
+
+// defaultHashDepthTypes is the outline numbering WithHashDepthTypes uses
+// when given no types: 1, a, i, A.
+var defaultHashDepthTypes = []MarkerType{Numeric, LowerAlpha, LowerRoman, UpperAlpha}
+
+// hashDepthType returns the type ("1", "a", "A", "i" or "I") of a list
+// opened by a bare '#' marker inside depth other lists. Entries that are not
+// a single marker family are numeric, as are all lists when no types are
+// configured.
+func (e *FancyListsOptions) hashDepthType(depth int) string {
+	if len(e.HashDepthTypes) == 0 {
+		return "1"
+	}
+	t := e.HashDepthTypes[depth%len(e.HashDepthTypes)]
+	for typ, family := range markerTypes {
+		if family == t {
+			return typ
+		}
+	}
+	return "1"
+}
+
+// enabledType returns the other reading of a letter marker's type, roman for
+// alphabetic and alphabetic for roman, when typ itself is disabled.
+func (e *FancyListsOptions) enabledType(typ string) string {
+	if e.typeEnabled(typ) {
+		return typ
+	}
+	switch typ {
+	case "a":
+		return "i"
+	case "A":
+		return "I"
+	case "i":
+		return "a"
+	case "I":
+		return "A"
+	}
+	return typ
+}
+
+// lettersEnabled reports whether an enabled marker family can read letters,
+// as an alphabetic marker or a roman numeral.
+func (e *FancyListsOptions) lettersEnabled(letters []byte) bool {
+	if e.Types == 0 || len(letters) == 0 {
+		return true
+	}
+	alpha, roman := "a", "i"
+	if !isLowerASCII(letters[0]) {
+		alpha, roman = "A", "I"
+	}
+	if e.typeEnabled(alpha) {
+		return true
+	}
+	_, ok := anyRomanToNumber(letters)
+	return ok && e.typeEnabled(roman)
+}
+
+// MarkerSpanMode selects whether ordered list markers are rendered as text.
+type MarkerSpanMode int
+
+const (
+	// MarkerSpansOff leaves the numbering to the browser.
+	MarkerSpansOff MarkerSpanMode = iota
+	// MarkerSpansList renders ordered lists as <ul> elements whose items
+	// begin with their marker in a span.
+	MarkerSpansList
+	// MarkerSpansOrdered keeps the <ol> element but drops its type and
+	// start, so the marker spans provide the only numbering.
+	MarkerSpansOrdered
+)
+
+// HeadingNumberMode selects whether and how headings are numbered.
+type HeadingNumberMode int
+
+const (
+	// HeadingNumbersOff leaves headings alone.
+	HeadingNumbersOff HeadingNumberMode = iota
+	// HeadingNumbersText writes the number before the heading text, as in
+	// "1.2 Usage".
+	HeadingNumbersText
+	// HeadingNumbersAttribute sets the number as the heading's data-number
+	// attribute, for CSS generated content.
+	HeadingNumbersAttribute
+)
+
+// LazyContinuationMode selects whether unindented lines may continue the
+// paragraph of a list item.
+type LazyContinuationMode int
+
+const (
+	// LazyCommonMark follows CommonMark: an unindented line that starts no
+	// other block continues the paragraph of the last item.
+	LazyCommonMark LazyContinuationMode = iota
+	// LazyIndented requires continuation lines to be indented to the item's
+	// content; an unindented line ends the list and starts a paragraph.
+	LazyIndented
+)
+
+// EmptyItemMode selects how list items without content are written.
+type EmptyItemMode int
+
+const (
+	// EmptyItemsInline writes "<li></li>".
+	EmptyItemsInline EmptyItemMode = iota
+	// EmptyItemsNewline writes "<li>" and "</li>" on separate lines.
+	EmptyItemsNewline
+	// EmptyItemsSelfClosing writes "<li />" when XHTML output is enabled
+	// with html.WithXHTML, and "<li></li>" otherwise, as a self-closing li
+	// is not valid HTML.
+	EmptyItemsSelfClosing
+)
+
+// InterruptPolicy selects which ordered lists may start in the middle of a
+// paragraph.
+type InterruptPolicy int
+
+const (
+	// InterruptFromOne lets only lists starting at 1 ("1.", "a.", "i.")
+	// interrupt a paragraph, as CommonMark does.
+	InterruptFromOne InterruptPolicy = iota
+	// InterruptLetters also lets alphabetic and roman lists with any start
+	// interrupt a paragraph; numeric lists must still start at 1.
+	InterruptLetters
+	// InterruptAny lets ordered lists with any start interrupt a paragraph.
+	InterruptAny
+)
+
+// LargeRomanPolicy selects what happens to roman numeral markers beyond the
+// classical range, such as "MMMMI.".
+type LargeRomanPolicy int
+
+const (
+	// LargeRomanAccept reads them as roman numerals with their computed
+	// value, 4001 for "MMMMI.".
+	LargeRomanAccept LargeRomanPolicy = iota
+	// LargeRomanAlpha reads them as alphabetic markers instead, if they
+	// have at most four letters, and does not treat longer ones as list
+	// markers.
+	LargeRomanAlpha
+	// LargeRomanReject does not treat them as list markers.
+	LargeRomanReject
+)
+
+// AlphaNumbering selects how multi-letter alphabetic markers are valued.
+type AlphaNumbering int
+
+const (
+	// AlphaBijective counts like spreadsheet columns and CSS lower-alpha:
+	// a-z are 1-26, then "aa." is 27 and "ba." is 53.
+	AlphaBijective AlphaNumbering = iota
+	// AlphaPositional reads letters as base-26 digits from a=0, plus one:
+	// a-z are still 1-26, but "ba." is 27 and "aa." equals "a.".
+	AlphaPositional
+)
+
+// ParserOrder selects where the list parsers are registered relative to the
+// block parsers of other extensions.
+type ParserOrder int
+
+const (
+	// ParsersFirst registers the list and list item parsers at priorities
+	// 100 and 101, ahead of the block parsers of most extensions.
+	ParsersFirst ParserOrder = iota
+	// ParsersLast registers them at priorities 298 and 299, just ahead of
+	// Goldmark's core list parser (300), so the list item parsers of other
+	// extensions registered ahead of it see each line first. The two stay
+	// adjacent because the list parser leaves lines to the item parser.
+	ParsersLast
+)
+
+// InlineStyleMode selects whether lists carry their list-style-type in a
+// style attribute, for output shown where the stylesheet cannot be loaded,
+// such as e-mail and feed readers.
+type InlineStyleMode int
+
+const (
+	// InlineStylesNone styles lists only through their classes.
+	InlineStylesNone InlineStyleMode = iota
+	// InlineStylesWithClasses writes a style attribute as well as the
+	// classes.
+	InlineStylesWithClasses
+	// InlineStylesOnly writes a style attribute instead of the classes the
+	// extension computes. User classes are still written.
+	InlineStylesOnly
+)
+
+// columnDelta returns how much further right the current line's content
+// starts than the content of the line that opened list, in lenient mode.
+// Adding it to a line's indentation measures both in source columns.
+func (e *FancyListsOptions) columnDelta(list ast.Node, reader text.Reader) int {
+	if e.Offsets != OffsetsLenient {
+		return 0
+	}
+	v, ok := list.Attribute(attrNameColumn)
+	if !ok {
+		return 0
+	}
+	base, ok := v.(int)
+	if !ok {
+		return 0
+	}
+	return reader.LineOffset() - base
+}
+
+// relaxedMarkerIndent is the indentation at which a marker line becomes
+// indented code when RelaxedIndent is set.
+const relaxedMarkerIndent = 8
+
+// markerIndent returns the indentation, relative to the enclosing list,
+// from which a line is no longer a list marker.
+func (e *FancyListsOptions) markerIndent() int {
+	if e.RelaxedIndent {
+		return relaxedMarkerIndent
+	}
+	return 4
+}
+
+// DefaultDeniedAttributes lists the attributes most often abused in
+// user-generated content: event handlers and inline styles. Pass it to
+// WithAttributeFilter to drop them.
+var DefaultDeniedAttributes = []string{"on*", "style"}
+
+// attributeAllowed reports whether a user attribute passes the configured
+// AllowedAttributes and DeniedAttributes. Names are compared ignoring case,
+// and a pattern ending in '*' matches any name with that prefix.
+func (e *FancyListsOptions) attributeAllowed(name []byte) bool {
+	if len(e.AllowedAttributes) > 0 && !matchesAttributePattern(e.AllowedAttributes, name) {
+		return false
+	}
+	return !matchesAttributePattern(e.DeniedAttributes, name)
+}
+
+// matchesAttributePattern reports whether name matches any of patterns.
+func matchesAttributePattern(patterns []string, name []byte) bool {
+	for _, p := range patterns {
+		if prefix, ok := strings.CutSuffix(p, "*"); ok {
+			if len(name) >= len(prefix) && strings.EqualFold(string(name[:len(prefix)]), prefix) {
+				return true
+			}
+		} else if strings.EqualFold(string(name), p) {
+			return true
+		}
+	}
+	return false
+}
+
+// ambiguousMarkerValue reports whether marker is a standalone i/v/x marker
+// governed by a configured AmbiguityPolicy, and returns its value under it.
+func (e *FancyListsOptions) ambiguousMarkerValue(marker []byte) (int, bool) {
+	if e.AmbiguousMarkers == AmbiguousContext || len(marker) != 1 {
+		return 0, false
+	}
+	var roman int
+	switch marker[0] | 0x20 {
+	case 'i':
+		roman = 1
+	case 'v':
+		roman = 5
+	case 'x':
+		roman = 10
+	default:
+		return 0, false
+	}
+	if e.AmbiguousMarkers == AmbiguousRoman {
+		return roman, true
+	}
+	return e.alphabetValue(marker), true
+}
+
+// ambiguousMarkerType returns the list type a standalone i/v/x marker
+// belongs to under the configured AmbiguityPolicy.
+func (e *FancyListsOptions) ambiguousMarkerType(c byte) string {
+	lower := isLowerASCII(c)
+	switch {
+	case e.AmbiguousMarkers == AmbiguousRoman && lower:
+		return "i"
+	case e.AmbiguousMarkers == AmbiguousRoman:
+		return "I"
+	case lower:
+		return "a"
+	}
+	return "A"
+}
+
+// MixedCasePolicy selects how letter markers that mix upper and lower case,
+// such as "Ii." or "xIv.", are handled.
+type MixedCasePolicy int
+
+const (
+	// MixedCaseNormalize reads the marker in the case of its first letter,
+	// so "Ii." continues or starts an uppercase roman list.
+	MixedCaseNormalize MixedCasePolicy = iota
+	// MixedCaseReject does not treat mixed-case markers as list markers.
+	MixedCaseReject
+	// MixedCaseDiagnose normalizes like MixedCaseNormalize and also records
+	// a Diagnostic for each mixed-case marker.
+	MixedCaseDiagnose
+)
+
+// Option configures a FancyListsOptions value created with NewFancyLists.
+type Option func(*FancyListsOptions)
+
+// NewFancyLists returns a FancyListsOptions extender configured with opts.
+// Calling it without options is equivalent to using FancyLists.
+func NewFancyLists(opts ...Option) *FancyListsOptions {
+	e := &FancyListsOptions{}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// WithClassMap writes the classes of m instead of the default ones for the
+// list types m maps, such as ClassMap{"a": "letters"}; other types keep
+// their default class. Pass the same map to Stylesheet to style them. The
+// map is copied, so changing it afterwards has no effect.
+func WithClassMap(m ClassMap) Option {
+	return func(e *FancyListsOptions) {
+		e.ClassMap = ClassMap(nil).with(m)
+	}
+}
+
+// WithCompact omits the newlines normally written after the opening list
+// tag, between items and after closing tags, so each list renders on a
+// single line. Content rendered by other node renderers (paragraphs inside
+// loose lists, for example) is not affected.
+func WithCompact() Option {
+	return func(e *FancyListsOptions) {
+		e.Compact = true
+	}
+}
+
+// WithMaxStart caps the start value a list may begin with. Large starts are
+// almost always a misclassification (prose such as "vi. something" computes
+// a start of 581) rather than intent. policy selects whether such markers
+// are rejected or clamped to max; CommonMark numeric markers such as
+// "5000." are clamped under either (see StartLimitReject). Item values set
+// with a hint such as "#5000." are capped the same way.
+func WithMaxStart(max int, policy StartLimitPolicy) Option {
+	return func(e *FancyListsOptions) {
+		e.MaxStart = max
+		e.MaxStartPolicy = policy
+	}
+}
+
+// WithPadding exposes the width of zero-padded numeric markers ("003.") as a
+// class or data attribute, so stylesheets can reproduce the padded labels.
+func WithPadding(mode PaddingMode) Option {
+	return func(e *FancyListsOptions) {
+		e.Padding = mode
+	}
+}
+
+// WithItemValues writes the parser-computed number of every ordered list item
+// as value="n" on its <li>. Some CMS sanitizers and PDF converters strip the
+// start attribute from <ol>, and per-item values keep the numbering intact.
+func WithItemValues() Option {
+	return func(e *FancyListsOptions) {
+		e.ItemValues = true
+	}
+}
+
+// WithAmbiguousMarkers sets a document-wide reading for standalone "i.",
+// "v." and "x." markers instead of the context-dependent heuristics, for
+// documents that consistently use one interpretation.
+func WithAmbiguousMarkers(policy AmbiguityPolicy) Option {
+	return func(e *FancyListsOptions) {
+		e.AmbiguousMarkers = policy
+	}
+}
+
+// WithMixedCase selects how letter markers that mix upper and lower case are
+// handled: normalized to the case of their first letter (the default),
+// rejected as list markers, or normalized with a Diagnostic recorded.
+func WithMixedCase(policy MixedCasePolicy) Option {
+	return func(e *FancyListsOptions) {
+		e.MixedCase = policy
+	}
+}
+
+// WithFullWidthMarkers accepts list markers written with full-width digits,
+// letters, parentheses and periods, such as "（a）", "１）" and "ｂ．", which
+// are common in Japanese and Chinese documents. They are normalized to the
+// matching list type, with "）" read as ")" and "．" as ".".
+func WithFullWidthMarkers() Option {
+	return func(e *FancyListsOptions) {
+		e.FullWidthMarkers = true
+	}
+}
+
+// WithArabicIndicDigits accepts numeric list markers written with
+// Arabic-Indic digits ("١.", "٢.") or the extended forms used for Persian and
+// Urdu ("۱)", "۲)"). Such lists get the computed start value, dir="rtl"
+// (unless a dir attribute is already set) and a data-digits attribute naming
+// the digit script for CSS.
+func WithArabicIndicDigits() Option {
+	return func(e *FancyListsOptions) {
+		e.ArabicIndicDigits = true
+	}
+}
+
+// WithAlphabet numbers alphabetic markers using the letters of alphabet, in
+// order, instead of a-z. Markers containing other letters are not treated as
+// list items, except roman numerals, which are always recognized. The
+// alphabet should consist of distinct ASCII letters; case is ignored, so
+// "abcdefghjk" numbers both "j." and "J." as 9. For example,
+// WithAlphabet("abcdefghjklmnpqrstuvwxyz") skips "i" and "o" as is common
+// in legal and engineering documents.
+func WithAlphabet(alphabet string) Option {
+	return func(e *FancyListsOptions) {
+		e.Alphabet = alphabet
+	}
+}
+
+// WithBlockAttributes applies an attribute line such as {.steps #intro
+// data-level="2"} placed directly below a list to that list, so fancy lists
+// can be styled without the goldmark-attributes extension. Attribute lines
+// that do not follow a list are left alone; when goldmark-attributes is also
+// registered it keeps handling them.
+func WithBlockAttributes() Option {
+	return func(e *FancyListsOptions) {
+		e.BlockAttributes = true
+	}
+}
+
+// WithAttributePolicy sets how user-supplied class and type attributes on
+// ordered lists combine with the computed ones. The default is
+// AttributesMerge.
+func WithAttributePolicy(policy AttributePolicy) Option {
+	return func(e *FancyListsOptions) {
+		e.AttributePolicy = policy
+	}
+}
+
+// WithAttributeFilter restricts the attributes passed through from
+// goldmark-attributes or WithBlockAttributes to lists. When allow is not
+// empty only the names it matches are written, and names matched by deny are
+// never written; class and type are governed by the AttributePolicy instead.
+// Patterns ignore case and may end in '*' to match a prefix, as in "on*" or
+// "data-*". The filter does not apply when html.WithUnsafe is set.
+func WithAttributeFilter(allow, deny []string) Option {
+	return func(e *FancyListsOptions) {
+		e.AllowedAttributes = allow
+		e.DeniedAttributes = deny
+	}
+}
+
+// WithCommonMarkOutput renders bullet lists and lists with ASCII decimal
+// markers exactly as Goldmark's core list renderer does: no fancy classes or
+// type attribute, and start only when it is not 1. Lists using any other
+// marker are rendered as usual. With this option the extension is a drop-in
+// replacement for the core list parser on CommonMark input.
+func WithCommonMarkOutput() Option {
+	return func(e *FancyListsOptions) {
+		e.CommonMark = true
+	}
+}
+
+// WithBareHashMarkers accepts "# item", without a delimiter, as the next item
+// of an open ordered list, as some wiki dialects write it. A bare '#' never
+// starts a list, so "# Title" outside a list, or after a blank line, is
+// still an ATX heading, but directly below an item of an ordered list such
+// a line becomes an item rather than a heading.
+func WithBareHashMarkers() Option {
+	return func(e *FancyListsOptions) {
+		e.BareHashMarkers = true
+	}
+}
+
+// WithChecklists renders ordered lists of task items ("1. [ ] step",
+// "a. [x] done") with checkboxes, for step-by-step runbooks. It enables the
+// task list syntax on its own, so GFM is not required. With
+// ChecklistReplace, lists whose items all start with a checkbox get the
+// ClassChecklist class, which Stylesheet uses to hide their numbers.
+func WithChecklists(mode ChecklistMode) Option {
+	return func(e *FancyListsOptions) {
+		e.Checklists = mode
+	}
+}
+
+// WithOffsets sets how the indentation of list lines inside block quotes is
+// measured. The default, OffsetsStrict, follows CommonMark.
+func WithOffsets(mode OffsetMode) Option {
+	return func(e *FancyListsOptions) {
+		e.Offsets = mode
+	}
+}
+
+// WithRelaxedIndent parses list markers indented four to seven spaces as
+// list items, nested under the item above when they are indented past its
+// content, instead of as indented code. Markdown.pl and other early
+// renderers behaved this way; enable it when migrating legacy content.
+// Indented code blocks then need eight spaces in front of a marker-like
+// line.
+func WithRelaxedIndent() Option {
+	return func(e *FancyListsOptions) {
+		e.RelaxedIndent = true
+	}
+}
+
+// WithTypes enables only the given families of ordered list markers, for
+// example WithTypes(Numeric, LowerRoman) to leave "a." and "A." lines as
+// text and avoid false positives in prose. A letter that is both (such as
+// "i" or "v") takes whichever reading is enabled. Decimal markers remain
+// CommonMark syntax: without Numeric, Goldmark's core parser still reads them
+// and they render as plain "<ol>" lists.
+func WithTypes(types ...MarkerType) Option {
+	return func(e *FancyListsOptions) {
+		for _, t := range types {
+			e.Types |= t
+		}
+	}
+}
+
+// WithReversedLists detects ordered lists whose markers count down by one,
+// such as "3. 2. 1.", "c. b. a." or "iii. ii. i.", and renders them as
+// <ol reversed> with the first marker as the start value, so browsers number
+// them the way they were written. Lists continued with '#' are never
+// reversed.
+func WithReversedLists() Option {
+	return func(e *FancyListsOptions) {
+		e.Reversed = true
+	}
+}
+
+// WithBulletClasses adds ClassDisc, ClassCircle or ClassSquare to bullet
+// lists according to how many lists contain them, following the browser
+// defaults, so themes can style bullets per level. Stylesheet includes
+// matching rules.
+func WithBulletClasses() Option {
+	return func(e *FancyListsOptions) {
+		e.BulletClasses = true
+	}
+}
+
+// WithBulletPassthrough leaves "-", "+" and "*" lists entirely to Goldmark's
+// core list parser and renders them as its core renderer does, so only
+// ordered lists are handled by this extension.
+func WithBulletPassthrough() Option {
+	return func(e *FancyListsOptions) {
+		e.BulletPassthrough = true
+	}
+}
+
+// WithDelimiterClasses adds ClassPeriod or ClassParen to ordered lists, after
+// the type class, according to whether their markers end in '.' or ')', so
+// CSS ::marker rules can reproduce the author's punctuation. Full-width
+// delimiters count as their ASCII forms.
+func WithDelimiterClasses() Option {
+	return func(e *FancyListsOptions) {
+		e.DelimiterClasses = true
+	}
+}
+
+// WithMarkerSpans writes each ordered item's marker, as written, at the
+// start of the item in <span class="fl-marker">, so designers can style and
+// position markers freely and copied text keeps them. The list gets the
+// ClassMarked class and no type or start attribute; Stylesheet hides the
+// browser's own numbers for it. '#' markers show the marker their value
+// would have been written with.
+func WithMarkerSpans(mode MarkerSpanMode) Option {
+	return func(e *FancyListsOptions) {
+		e.MarkerSpans = mode
+	}
+}
+
+// WithMinimalOutput renders lists for comment and chat systems whose HTML
+// goes through a sanitizer: <ol> carries only a type attribute, omitted for
+// decimal lists, and no classes, data attributes or user attributes are
+// written. Common sanitizers such as bluemonday's UGC policy drop start, so
+// items of lists that do not count up from 1 carry a value attribute
+// instead. Marker spans are not written in this mode.
+func WithMinimalOutput() Option {
+	return func(e *FancyListsOptions) {
+		e.Minimal = true
+	}
+}
+
+// WithSemanticOutput renders lists as they would be written by hand: <ol>
+// carries only type and start, each omitted when it has its default value,
+// and reversed for lists counting down. No classes, data attributes or user
+// attributes are written, and items carry a value only where the source
+// sets one explicitly ("#5.") or WithItemValues asks for it. Marker spans
+// and microdata are not written in this mode. WithMinimalOutput takes
+// precedence when both are set.
+func WithSemanticOutput() Option {
+	return func(e *FancyListsOptions) {
+		e.Semantic = true
+	}
+}
+
+// WithMicrodata annotates lists with schema.org microdata, so how-to and
+// recipe pages get structured data without extra markup: lists get
+// itemscope and itemtype="https://schema.org/ItemList", and each item is an
+// itemListElement of type ListItem whose position is given by a <meta>
+// element at its start. The attributes follow the extension's data
+// attributes. Minimal output is not annotated.
+func WithMicrodata() Option {
+	return func(e *FancyListsOptions) {
+		e.Microdata = true
+	}
+}
+
+// WithStepMarkers accepts markers such as "Step 1." and "Step b)", common in
+// tutorial content imported from other systems, as ordered list markers.
+// The word "Step" and a single space must directly precede the counter;
+// case is ignored. The prefix is kept as written in the list's
+// data-prefix attribute, and a list only continues with items that have
+// the same prefix.
+func WithStepMarkers() Option {
+	return func(e *FancyListsOptions) {
+		e.StepMarkers = true
+	}
+}
+
+// WithMarkerPrefixes accepts ordered markers preceded by any of prefixes,
+// so domain syntaxes such as "Q1." (prefix "Q") or "Task 3)" (prefix
+// "Task ") become ordered lists numbered by their counters. A prefix must
+// directly precede the counter, so include any separating space in it; case
+// is ignored. After a prefix the counter must be a number, a single letter,
+// a roman numeral or a '#' marker. Prefixes behave as described for
+// WithStepMarkers, which is the same as WithMarkerPrefixes("Step ").
+func WithMarkerPrefixes(prefixes ...string) Option {
+	return func(e *FancyListsOptions) {
+		e.MarkerPrefixes = append(e.MarkerPrefixes, prefixes...)
+	}
+}
+
+// WithLegalMarkers accepts the section and article markers of contracts and
+// statutes, "§ 1." and "Article I.", as ordered list markers. Counters after
+// "Article " that are valid roman numerals are read as such, so "Article
+// V." is 5 rather than the letter v. Prefixes behave as described for
+// WithStepMarkers.
+func WithLegalMarkers() Option {
+	return func(e *FancyListsOptions) {
+		e.LegalMarkers = true
+	}
+}
+
+// WithOrdinalMarkers accepts numeric list markers followed by an ordinal
+// suffix, as written in Spanish, Portuguese and Italian documents: "1º",
+// "2ª." or "3°)". The masculine and feminine ordinal indicators and the
+// degree sign are accepted, with or without a following delimiter. The
+// suffix is kept in a data-suffix attribute for CSS, and a list continues
+// only with markers that have the same suffix.
+func WithOrdinalMarkers() Option {
+	return func(e *FancyListsOptions) {
+		e.OrdinalMarkers = true
+	}
+}
+
+// WithDoubleDelimiters accepts ordered list markers ending in the combined
+// delimiter ".)", as in "1.)" or "a.)", which documents exported from word
+// processors often use. The delimiter is normalized to '.', so such items
+// continue lists written with "1." and render like them; Delimiter reports
+// the form that was written.
+func WithDoubleDelimiters() Option {
+	return func(e *FancyListsOptions) {
+		e.DoubleDelimiters = true
+	}
+}
+
+// WithHashDepthTypes numbers lists opened by a bare '#.' or '#)' marker by
+// their nesting depth, so authors can write structure-only markers and get
+// outline numbering: the outermost list takes the first type, lists nested
+// in it the second, and so on, starting over after the last. Without types
+// the cycle is Numeric, LowerAlpha, LowerRoman, UpperAlpha (1, a, i, A).
+// Lists opened by any other marker, including '#' with a value hint such as
+// "#5.", keep the type of that marker.
+func WithHashDepthTypes(types ...MarkerType) Option {
+	return func(e *FancyListsOptions) {
+		if len(types) == 0 {
+			types = defaultHashDepthTypes
+		}
+		e.HashDepthTypes = append([]MarkerType(nil), types...)
+	}
+}
+
+// WithOutlineNumbering rewrites nested bullet lists as ordered lists typed
+// by nesting depth, for outline-style numbering without editing the source.
+// A bullet list not inside another list is rewritten, together with every
+// bullet list nested in it, when it contains a nested list; flat bullet
+// lists are left alone, as are ordered lists, which still count toward the
+// depth. Types cycle as for WithHashDepthTypes, and without types the cycle
+// is 1, a, i, A.
+func WithOutlineNumbering(types ...MarkerType) Option {
+	return func(e *FancyListsOptions) {
+		if len(types) == 0 {
+			types = defaultHashDepthTypes
+		}
+		e.OutlineTypes = append([]MarkerType(nil), types...)
+	}
+}
+
+// WithHeadingNumbers numbers headings the way nested lists are numbered,
+// "1", "1.1", "1.1.a", using types by depth (1, 1, a, i when none are
+// given, and cycling after the last). Each heading nests at most one level
+// below the one before it, so a document whose first heading is an h2 is
+// numbered from its h2s. Numbering happens after parsing, so ids from
+// parser.WithAutoHeadingID do not include the numbers.
+func WithHeadingNumbers(mode HeadingNumberMode, types ...MarkerType) Option {
+	return func(e *FancyListsOptions) {
+		if len(types) == 0 {
+			types = defaultHeadingTypes
+		}
+		e.HeadingNumbers = mode
+		e.HeadingTypes = append([]MarkerType(nil), types...)
+	}
+}
+
+// WithQuizzes renders uppercase alphabetic lists carrying a quiz attribute,
+// such as "{quiz=q1}" set with goldmark-attributes or WithBlockAttributes,
+// as multiple-choice questions: each item is wrapped in a label with a
+// radio input named after the attribute's value and valued with the item's
+// letter, and the list gets the ClassQuiz class. The quiz attribute itself
+// is not written. Other lists are rendered as usual.
+func WithQuizzes() Option {
+	return func(e *FancyListsOptions) {
+		e.Quizzes = true
+	}
+}
+
+// WithCommentContinuation treats HTML comments between two lists of the same
+// type as editorial notes rather than list breaks: the lists are merged,
+// the second list's items are numbered on from the first, and the comments
+// are kept in the first list's last item. CommonMark otherwise uses a
+// comment to end a list, so this is opt-in. Lists with different markers,
+// types, prefixes or suffixes, and reversed lists, are not merged.
+func WithCommentContinuation() Option {
+	return func(e *FancyListsOptions) {
+		e.CommentContinuation = true
+	}
+}
+
+// WithDoubleBlankLineEnd ends the current list, and every list it is nested
+// in, at two consecutive blank lines, even if the content that follows is
+// indented enough to belong to the last item. Blank lines inside a fenced
+// code block do not end its list.
+func WithDoubleBlankLineEnd() Option {
+	return func(e *FancyListsOptions) {
+		e.DoubleBlankLineEnd = true
+	}
+}
+
+// WithLazyContinuation selects how lines that are not indented to a list
+// item's content are treated. LazyCommonMark, the default, lets such a line
+// continue the paragraph of the last item, as CommonMark's lazy
+// continuation rule does. LazyIndented ends the list at such a line and
+// starts a new paragraph, so every line of an item must be indented.
+func WithLazyContinuation(mode LazyContinuationMode) Option {
+	return func(e *FancyListsOptions) {
+		e.LazyContinuation = mode
+	}
+}
+
+// WithEmptyItems selects how list items without content, such as a bare
+// "b." line, are written: "<li></li>" (EmptyItemsInline, the default),
+// "<li>" and "</li>" on separate lines (EmptyItemsNewline), or "<li />" in
+// XHTML output (EmptyItemsSelfClosing). HTML diffing tools and sanitizers
+// treat these differently.
+func WithEmptyItems(mode EmptyItemMode) Option {
+	return func(e *FancyListsOptions) {
+		e.EmptyItems = mode
+	}
+}
+
+// WithHangingIndent sets the indent, counted from the start of the marker,
+// that continuation lines and nested blocks of an item must reach. CommonMark
+// aligns them to the item's content, the marker width plus its spaces, so
+// "iii. " needs five spaces; with WithHangingIndent(2) or (4) every item
+// takes the same fixed indent, as many editors auto-indent and as Pandoc's
+// four-space rule expects. Zero or less keeps the CommonMark rule.
+func WithHangingIndent(n int) Option {
+	return func(e *FancyListsOptions) {
+		e.HangingIndent = n
+	}
+}
+
+// WithParagraphInterrupt relaxes the rule that only ordered lists starting
+// at 1 may interrupt a paragraph, for content where lists often continue
+// mid-paragraph. InterruptLetters relaxes it for alphabetic and roman lists,
+// whose markers rarely begin ordinary sentences; InterruptAny relaxes it for
+// numeric lists too, so a line such as "2020. was a year" in a paragraph
+// becomes a list. Items without content still never interrupt a paragraph.
+func WithParagraphInterrupt(policy InterruptPolicy) Option {
+	return func(e *FancyListsOptions) {
+		e.Interrupt = policy
+	}
+}
+
+// WithLargeRoman selects what happens to markers read as roman numerals
+// whose value is above 3999, the largest classical numeral (MMMCMXCIX):
+// accept them with their computed value (LargeRomanAccept, the default),
+// read them as alphabetic markers (LargeRomanAlpha), or leave them as text
+// (LargeRomanReject). Any marker that is a valid numeral above 3999, such
+// as "MMMMI.", is read this way, not only those starting with 'i' or 'I'.
+// LargeRomanAlpha reads only four-letter numerals such as "MMMM." as
+// letters; longer ones, whose alphabetic values run into the millions, are
+// left as text. The policy also applies to value hints such as "#MMMMI." in
+// roman lists. Labels for values above 3999, as in marker spans, are
+// written as numbers.
+func WithLargeRoman(policy LargeRomanPolicy) Option {
+	return func(e *FancyListsOptions) {
+		e.LargeRoman = policy
+	}
+}
+
+// WithUnicodeRoman accepts list markers written with the dedicated roman
+// numeral characters, uppercase (U+2160–U+216F, "Ⅲ.") or lowercase
+// (U+2170–U+217F, "ⅻ)"), alone or in sequence ("ⅩⅣ."). They are always
+// read as roman numerals and render like their ASCII spelling, with the
+// upper-roman or lower-roman class.
+func WithUnicodeRoman() Option {
+	return func(e *FancyListsOptions) {
+		e.UnicodeRoman = true
+	}
+}
+
+// WithParenthesizedMarkers accepts the parenthesized digit and letter
+// characters that word processors produce for "(1)" and "(a)" markers:
+// "⑴" to "⒇", "⒜" to "⒵" and "🄐" to "🄩". Each is read as the digit or
+// letter it encloses with a ')' delimiter, so "⒝" starts a lower-alpha list
+// at 2 and continues with "c)" as well as "⒞".
+func WithParenthesizedMarkers() Option {
+	return func(e *FancyListsOptions) {
+		e.ParenthesizedMarkers = true
+	}
+}
+
+// WithBullets accepts the characters in bullets as bullet list markers
+// besides '-', '+' and '*', for documents converted from rich text:
+// WithBullets("•◦▪") reads "• item" as a bullet list item. As with the
+// ASCII bullets, changing the bullet character starts a new list. ASCII
+// characters in bullets are ignored, since they already have a meaning in
+// Markdown.
+func WithBullets(bullets string) Option {
+	return func(e *FancyListsOptions) {
+		e.Bullets = bullets
+	}
+}
+
+// WithDashBullets accepts the en dash ('–') and the em dash ('—') followed
+// by a space as bullet list markers, as pasted prose often uses them. The
+// items render as any other bullet list; each dash starts its own list, as
+// with WithBullets.
+func WithDashBullets() Option {
+	return func(e *FancyListsOptions) {
+		e.DashBullets = true
+	}
+}
+
+// WithAlphaNumbering selects how multi-letter alphabetic markers are valued.
+// With AlphaBijective, the default, letters count like spreadsheet columns:
+// "z." starts at 26, "aa." at 27 and "ba." at 53. With AlphaPositional they
+// are base-26 digits from a=0, plus one: "z." still starts at 26, but "ba."
+// starts at 27 and "aa." at 1. Labels written by the extension, as in marker
+// spans, follow the same numbering. Browsers always number lower-alpha and
+// upper-alpha lists bijectively, so a positional "ba." list displays as
+// "aa." unless marker spans are used.
+func WithAlphaNumbering(numbering AlphaNumbering) Option {
+	return func(e *FancyListsOptions) {
+		e.AlphaNumbering = numbering
+	}
+}
+
+// WithAbbreviations replaces the stop-list of abbreviations, written with
+// their delimiter as in "vs." or "etc.", that are never read as alphabetic
+// list items: "vs. the others" stays a paragraph instead of becoming a list
+// starting at 591. Case is ignored. The default stop-list, used without
+// this option or without abbreviations, is "vs.", "etc.", "e.g.", "no."
+// and "ca.".
+func WithAbbreviations(abbreviations ...string) Option {
+	return func(e *FancyListsOptions) {
+		if len(abbreviations) == 0 {
+			e.Abbreviations = nil
+			return
+		}
+		e.Abbreviations = append([]string(nil), abbreviations...)
+	}
+}
+
+// WithoutAbbreviations turns the stop-list off, so that every letter marker,
+// "etc." included, may start a list.
+func WithoutAbbreviations() Option {
+	return func(e *FancyListsOptions) {
+		e.Abbreviations = []string{}
+	}
+}
+
+// WithTrace calls trace with every decision the list parsers make: each line
+// that opens a list, with the type and start chosen, each item, each line
+// that may start with a marker but could not open a list, and each line at
+// which a list was closed, with the reason. Lines starting with letters and
+// no delimiter, which are ordinary prose, are not reported. It is meant for
+// debugging mis-parsed documents, for example with
+//
+//	WithTrace(func(t TraceEvent) { log.Println(t) })
+//
+// trace is called during parsing, so it must be safe for concurrent use if
+// the Markdown instance is.
+func WithTrace(trace func(TraceEvent)) Option {
+	return func(e *FancyListsOptions) {
+		e.Trace = trace
+	}
+}
+
+// WithFancyRegions honors fancy markers only inside fenced containers
+// named name, in the syntax of Pandoc's fenced divs:
+//
+//	::: fancy
+//	a. First
+//	b. Second
+//	:::
+//
+// Everywhere else only the markers CommonMark defines start lists, and
+// plain lists render as Goldmark's core renderer would, so a large
+// document can adopt the syntax one section at a time. The fences are not
+// rendered. name defaults to "fancy".
+func WithFancyRegions(name string) Option {
+	return func(e *FancyListsOptions) {
+		if name == "" {
+			name = defaultRegion
+		}
+		e.Regions = name
+	}
+}
+
+// WithInlineStyles writes the list-style-type of fancy lists in a style
+// attribute, alongside or instead of their classes, so the numbering stays
+// right where external CSS cannot be loaded:
+//
+//	<ol class="fancy fl-lcroman" type="i" start="1" style="list-style-type: lower-roman">
+//
+// A style the user sets, if passed through, is appended to the declaration.
+func WithInlineStyles(mode InlineStyleMode) Option {
+	return func(e *FancyListsOptions) {
+		e.InlineStyles = mode
+	}
+}
+
+// WithIndentedOutput indents list and item tags, and the paragraphs of
+// loose items, by their nesting depth, writing indent once per level
+// (two spaces if empty), so rendered HTML reads well in golden files and
+// documentation snapshots:
+//
+//	<ol class="fancy fl-lcalpha" type="a" start="1">
+//	  <li>One
+//	    <ul>
+//	      <li>Nested</li>
+//	    </ul>
+//	  </li>
+//	</ol>
+//
+// Other blocks inside items, such as code blocks whose content cannot be
+// indented, start at the beginning of the line. It has no effect with
+// WithCompact.
+func WithIndentedOutput(indent string) Option {
+	return func(e *FancyListsOptions) {
+		if indent == "" {
+			indent = defaultIndent
+		}
+		e.Indent = indent
+	}
+}
+
+// WithListItemRenderer hands the rendering of every list item to render,
+// which writes the opening tag and anything before the item's content when
+// entering, and anything after it and the closing tag when leaving, for
+// example to add icons or wrap the content:
+//
+//	fancylists.WithListItemRenderer(func(w util.BufWriter, item *ast.ListItem, entering bool) error {
+//		if entering {
+//			_, err := w.WriteString(`<li><span class="icon"></span>`)
+//			return err
+//		}
+//		_, err := w.WriteString("</li>\n")
+//		return err
+//	})
+//
+// The list tags are still written by the extension with all of its
+// options. Item values, marker spans, quiz inputs and microdata are left to
+// render; ItemValue and MarkerSegment give what it needs to write them.
+func WithListItemRenderer(render ListItemRenderer) Option {
+	return func(e *FancyListsOptions) {
+		e.ListItemRenderer = render
+	}
+}
+
+// WithParserOrder sets where the list parsers are registered among the block
+// parsers of other extensions, which decides which of those parsers are
+// offered a line before them. In either order the list parsers shadow
+// Goldmark's core ones, which stay registered behind them (see Extend).
+func WithParserOrder(order ParserOrder) Option {
+	return func(e *FancyListsOptions) {
+		e.ParserOrder = order
+	}
+}
+
+// WithAttributeProviders reads the user attributes of lists from the
+// attribute syntaxes of providers, in addition to goldmark-attributes and
+// WithBlockAttributes, which set them on lists directly. Provided attributes
+// go through WithAttributePolicy and WithAttributeFilter like any other, and
+// never replace an attribute already set. AttributeBlocks and
+// FirstLineAttributes cover common syntaxes.
+func WithAttributeProviders(providers ...AttributeProvider) Option {
+	return func(e *FancyListsOptions) {
+		e.AttributeProviders = append(e.AttributeProviders, providers...)
+	}
+}
+
+// WithBoundaryComments writes an HTML comment before and after every list
+// the extension renders, except those rendered exactly as Goldmark's core
+// renderer would, so post-processors such as paginators and PDF engines can
+// find fancy lists without reading their attributes:
+//
+//	<!-- fl:start type=lcroman -->
+//	<ol class="fancy fl-lcroman" type="i" start="1">
+//	...
+//	</ol>
+//	<!-- fl:end -->
+//
+// The type is that of the list's class without the "fl-" prefix (num,
+// lcalpha, ucalpha, lcroman or ucroman), or bullet for bullet lists. Nested
+// lists get comments of their own.
+func WithBoundaryComments() Option {
+	return func(e *FancyListsOptions) {
+		e.BoundaryComments = true
+	}
+}
+
+// WithRenumbering ignores the numbers of list markers and numbers every
+// ordered list 1, 2, 3, ..., like CommonMark renderers with start numbers
+// turned off, for sites that treat the numbers authors type as noise. Lists
+// are rendered without a start attribute and items without values, except
+// with WithItemValues. The markers still choose the list type, so "c. d."
+// is an alphabetic list numbered a, b. Lists that count down are numbered
+// up.
+func WithRenumbering() Option {
+	return func(e *FancyListsOptions) {
+		e.Renumber = true
+	}
+}
+
+// WithOmitNumericType leaves out type="1", the HTML default, on numeric
+// lists, while alphabetic and roman lists keep their type attribute. The
+// fl-num class still marks numeric lists.
+func WithOmitNumericType() Option {
+	return func(e *FancyListsOptions) {
+		e.OmitNumericType = true
+	}
+}
+
+// WithItemLabels writes the label of each ordered item, as its marker shows
+// it without prefix or delimiter, in a data-label attribute:
+//
+//	<li data-label="iv">
+//
+// CSS can then show it with content: attr(data-label), and scripts can
+// read it without numbering the list again. Labels follow the list's type,
+// padding and Alphabet, and the values of '#' markers. Minimal and semantic
+// output have no labels.
+func WithItemLabels() Option {
+	return func(e *FancyListsOptions) {
+		e.ItemLabels = true
+	}
+}
@@ -0,0 +1,85 @@
+package fancylists
+
+import (
+	"bytes"
+	"unicode/utf8"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/util"
+)
+
+// attrNameSuffix holds the ordinal suffix of markers such as "1º".
+var attrNameSuffix = []byte("fl-suffix")
+
+// isOrdinalSuffix reports whether r is one of the suffixes accepted by
+// OrdinalMarkers: the masculine and feminine ordinal indicators and the
+// degree sign often typed in their place.
+func isOrdinalSuffix(r rune) bool {
+	return r == 'º' || r == 'ª' || r == '°'
+}
+
+// parseOrdinalListItem is the counterpart of parseListItem for numeric
+// markers followed by an ordinal suffix, as in Spanish, Portuguese and
+// Italian documents: "1º", "2ª." or "3°)". The delimiter after the suffix
+// is optional.
+func parseOrdinalListItem(line []byte) ([6]int, listItemType) {
+	ret := [6]int{}
+	i := 0
+	l := len(line)
+	for ; i < l && i <= 3 && line[i] == ' '; i++ {
+	}
+	if i > 3 || i >= l {
+		return ret, notList
+	}
+	ret[1] = i
+	ret[2] = i
+
+	digits := 0
+	for ; i < l && util.IsNumeric(line[i]); i++ {
+		digits++
+	}
+	if digits == 0 || digits > 9 || i >= l {
+		return ret, notList
+	}
+	r, size := utf8.DecodeRune(line[i:])
+	if !isOrdinalSuffix(r) {
+		return ret, notList
+	}
+	i += size
+	if i < l && (line[i] == '.' || line[i] == ')') {
+		i++
+	}
+	ret[3] = i
+	return finishListItem(line, i, ret, orderedList)
+}
+
+// ordinalSuffix returns the ordinal suffix of a matched marker, or nil.
+func ordinalSuffix(line []byte, match [6]int) []byte {
+	for i := match[2]; i < match[3]; i++ {
+		if line[i] < utf8.RuneSelf {
+			continue
+		}
+		r, size := utf8.DecodeRune(line[i:])
+		if isOrdinalSuffix(r) {
+			return line[i : i+size]
+		}
+		return nil
+	}
+	return nil
+}
+
+// listSuffix returns the ordinal suffix recorded for a list, or nil.
+func listSuffix(n ast.Node) []byte {
+	if v, ok := n.Attribute(attrNameSuffix); ok {
+		if suffix, ok := v.([]byte); ok {
+			return suffix
+		}
+	}
+	return nil
+}
+
+// sameSuffix reports whether a matched marker may continue list: both have
+// the same ordinal suffix, or neither has one.
+func sameSuffix(list ast.Node, line []byte, match [6]int) bool {
+	return bytes.Equal(ordinalSuffix(line, match), listSuffix(list))
+}
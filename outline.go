@@ -0,0 +1,68 @@
+package fancylists
+
+import (
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+// outlineTransformer rewrites nested bullet lists as ordered lists typed by
+// nesting depth (see WithOutlineNumbering).
+type outlineTransformer struct {
+	types []MarkerType
+}
+
+// NewOutlineTransformer returns an AST transformer that rewrites nested
+// bullet lists as ordered lists, the way WithOutlineNumbering does, for
+// parsers configured without this extension's options. Register it with
+// parser.WithASTTransformers at a priority below 1000.
+func NewOutlineTransformer(types ...MarkerType) parser.ASTTransformer {
+	if len(types) == 0 {
+		types = defaultHashDepthTypes
+	}
+	return &outlineTransformer{append([]MarkerType(nil), types...)}
+}
+
+func (t *outlineTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	e := &FancyListsOptions{HashDepthTypes: t.types}
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		list, ok := n.(*ast.List)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+		// Only outlines, bullet lists with lists nested in them, are rewritten
+		if !list.IsOrdered() && hasNestedList(list) {
+			_ = ast.Walk(list, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+				if l, ok := n.(*ast.List); entering && ok && !l.IsOrdered() {
+					numberList(l, e.hashDepthType(listDepth(l)), pc)
+				}
+				return ast.WalkContinue, nil
+			})
+		}
+		return ast.WalkSkipChildren, nil
+	})
+}
+
+// hasNestedList reports whether any item of list contains another list.
+func hasNestedList(list *ast.List) bool {
+	found := false
+	_ = ast.Walk(list, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if n != ast.Node(list) && n.Kind() == ast.KindList {
+			found = true
+			return ast.WalkStop, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return found
+}
+
+// numberList turns a bullet list into an ordered list of type typ starting
+// at 1.
+func numberList(list *ast.List, typ string, pc parser.Context) {
+	list.Marker = '.'
+	list.Start = 1
+	if v := typeValue(typ); v != nil {
+		list.SetAttribute(attrNameListType, v)
+		addTypedList(pc, list)
+	}
+}
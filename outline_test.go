@@ -0,0 +1,33 @@
+package fancylists
+
+import (
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+func TestNewOutlineTransformer(t *testing.T) {
+	md := goldmark.New(goldmark.WithParserOptions(
+		parser.WithASTTransformers(util.Prioritized(NewOutlineTransformer(UpperAlpha), 999)),
+	))
+	source := []byte("- One\n  - Sub\n")
+	doc := md.Parser().Parse(text.NewReader(source))
+
+	var types []string
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if list, ok := n.(*ast.List); ok && entering {
+			if !list.IsOrdered() || list.Start != 1 {
+				t.Errorf("list is not ordered from 1: marker %q, start %d", list.Marker, list.Start)
+			}
+			types = append(types, listType(list))
+		}
+		return ast.WalkContinue, nil
+	})
+	if len(types) != 2 || types[0] != "A" || types[1] != "A" {
+		t.Errorf("types = %q; want [A A]", types)
+	}
+}
@@ -0,0 +1,66 @@
+package fancylists
+
+import "unicode/utf8"
+
+// Parenthesized characters accepted when ParenthesizedMarkers is enabled.
+const (
+	parenDigitOne    = '⑴' // U+2474, parenthesized digits run to twenty
+	parenDigitTwenty = '⒇' // U+2487
+	parenSmallA      = '⒜' // U+249C
+	parenSmallZ      = '⒵' // U+24B5
+	parenCapitalA    = '🄐' // U+1F110
+	parenCapitalZ    = '🄩' // U+1F129
+)
+
+// isParenthesized reports whether r is a parenthesized digit or letter such
+// as '⑴' or '⒜'.
+func isParenthesized(r rune) bool {
+	return r >= parenDigitOne && r <= parenDigitTwenty ||
+		r >= parenSmallA && r <= parenSmallZ ||
+		r >= parenCapitalA && r <= parenCapitalZ
+}
+
+// foldParenthesized appends the ASCII form of the parenthesized character r
+// to text: '⑿' becomes "12" and '⒜' becomes "a".
+func foldParenthesized(text []byte, r rune) []byte {
+	switch {
+	case r >= parenSmallA && r <= parenSmallZ:
+		return append(text, byte(r-parenSmallA+'a'))
+	case r >= parenCapitalA:
+		return append(text, byte(r-parenCapitalA+'A'))
+	}
+	n := int(r - parenDigitOne + 1)
+	if n >= 10 {
+		text = append(text, byte('0'+n/10))
+	}
+	return append(text, byte('0'+n%10))
+}
+
+// parseParenthesizedListItem is the counterpart of parseListItem for the
+// single-character markers word processors produce, such as "⑴" and "⒜".
+// The character holds both the marker and its parentheses, so it is
+// followed directly by the item content.
+func parseParenthesizedListItem(line []byte) ([6]int, listItemType) {
+	ret := [6]int{}
+	i := 0
+	l := len(line)
+	for ; i < l && i <= 3 && line[i] == ' '; i++ {
+	}
+	if i > 3 || i >= l {
+		return ret, notList
+	}
+	ret[1] = i
+	ret[2] = i
+
+	r, size := utf8.DecodeRune(line[i:])
+	if !isParenthesized(r) {
+		return ret, notList
+	}
+	typ := orderedListFancy
+	if r <= parenDigitTwenty {
+		typ = orderedList
+	}
+	i += size
+	ret[3] = i
+	return finishListItem(line, i, ret, typ)
+}
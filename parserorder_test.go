@@ -0,0 +1,177 @@
+package fancylists
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// lineClaimer stands for another extension's block parser: it claims
+// every line starting with "a. " as a thematic break.
+type lineClaimer struct{}
+
+func (p lineClaimer) Trigger() []byte { return []byte("a") }
+
+func (p lineClaimer) Open(parent ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	line, segment := reader.PeekLine()
+	if !bytes.HasPrefix(line, []byte("a. ")) {
+		return nil, parser.NoChildren
+	}
+	reader.Advance(segment.Len() - 1)
+	return ast.NewThematicBreak(), parser.NoChildren
+}
+
+func (p lineClaimer) Continue(node ast.Node, reader text.Reader, pc parser.Context) parser.State {
+	return parser.Close
+}
+
+func (p lineClaimer) Close(node ast.Node, reader text.Reader, pc parser.Context) {}
+
+func (p lineClaimer) CanInterruptParagraph() bool { return true }
+
+func (p lineClaimer) CanAcceptIndentedLine() bool { return false }
+
+func TestCompetingBlockParser(t *testing.T) {
+	for _, tc := range []struct {
+		order ParserOrder
+		want  string
+	}{
+		{ParsersFirst, "<ol class=\"fancy fl-lcalpha\" type=\"a\" start=\"1\">\n<li>One</li>\n</ol>\n"},
+		{ParsersLast, "<hr>\n"},
+	} {
+		// Registered between the two positions, next to the list parsers
+		// of ParsersFirst
+		md := goldmark.New(
+			goldmark.WithExtensions(NewFancyLists(WithParserOrder(tc.order))),
+			goldmark.WithParserOptions(parser.WithBlockParsers(util.Prioritized(lineClaimer{}, 102))),
+		)
+		var buf bytes.Buffer
+		if err := md.Convert([]byte("a. One\n"), &buf); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tc.want {
+			t.Errorf("order %d: got:\n%s\nwant:\n%s", tc.order, buf.String(), tc.want)
+		}
+	}
+}
+
+// itemLineRecorder stands for another extension's list item parser: it sees
+// the lines offered to it inside lists and claims none.
+type itemLineRecorder struct {
+	lines []string
+}
+
+func (p *itemLineRecorder) Trigger() []byte { return []byte("ab") }
+
+func (p *itemLineRecorder) Open(parent ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	if _, ok := parent.(*ast.List); ok {
+		line, _ := reader.PeekLine()
+		p.lines = append(p.lines, strings.TrimSpace(string(line)))
+	}
+	return nil, parser.NoChildren
+}
+
+func (p *itemLineRecorder) Continue(node ast.Node, reader text.Reader, pc parser.Context) parser.State {
+	return parser.Close
+}
+
+func (p *itemLineRecorder) Close(node ast.Node, reader text.Reader, pc parser.Context) {}
+
+func (p *itemLineRecorder) CanInterruptParagraph() bool { return true }
+
+func (p *itemLineRecorder) CanAcceptIndentedLine() bool { return false }
+
+func TestParserOrder(t *testing.T) {
+	for _, tc := range []struct {
+		order ParserOrder
+		want  string
+	}{
+		{ParsersFirst, ""},
+		{ParsersLast, "a. One|b. Two"},
+	} {
+		recorder := &itemLineRecorder{}
+		md := goldmark.New(
+			goldmark.WithExtensions(NewFancyLists(WithParserOrder(tc.order))),
+			goldmark.WithParserOptions(parser.WithBlockParsers(util.Prioritized(recorder, 200))),
+		)
+		var buf bytes.Buffer
+		if err := md.Convert([]byte("a. One\nb. Two\n"), &buf); err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Join(recorder.lines, "|"); got != tc.want {
+			t.Errorf("order %d: lines seen by the other parser = %q; want %q", tc.order, got, tc.want)
+		}
+		if !strings.Contains(buf.String(), `<li>Two</li>`) {
+			t.Errorf("order %d: second item not parsed:\n%s", tc.order, buf.String())
+		}
+	}
+}
+
+// TestShadowingContract checks the contract between the extension's list
+// parsers and Goldmark's core ones, which stay registered behind them: every
+// list is opened by one or the other, its items come from the same parser,
+// and the core parser only opens the lists the options leave to it.
+func TestShadowingContract(t *testing.T) {
+	data, err := os.ReadFile("testdata/commonmark_lists.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cases []commonMarkCase
+	if err := json.Unmarshal(data, &cases); err != nil {
+		t.Fatal(err)
+	}
+	sources := []string{
+		"a. One\nb. Two\n1. Three\n",
+		"i. One\nii. Two\n- Three\n* Four\n",
+		"1. One\n2. Two\n   a) Nested\n   b) Nested\n3. Three\n",
+		"- One\n- Two\n\n  A. Nested\n  B. Nested\n",
+		"#. One\n#. Two\n#5. Five\n",
+		"• One\n• Two\n- Three\n",
+		"> a. Quoted\n> 1. Quoted\n\n1) One\nb) Two\n",
+	}
+	for _, c := range cases {
+		sources = append(sources, c.Markdown)
+	}
+
+	for _, tc := range []struct {
+		name string
+		opts []Option
+		// core reports whether the core parser may open list
+		core func(list *ast.List) bool
+	}{
+		{"default", nil, func(*ast.List) bool { return false }},
+		{"parsers last", []Option{WithParserOrder(ParsersLast)}, func(*ast.List) bool { return false }},
+		{"bullet passthrough", []Option{WithBulletPassthrough()}, func(l *ast.List) bool { return !l.IsOrdered() }},
+		{"no numeric type", []Option{WithTypes(LowerAlpha, UpperAlpha, LowerRoman, UpperRoman)}, func(l *ast.List) bool { return l.IsOrdered() }},
+		{"regions", []Option{WithFancyRegions("")}, func(*ast.List) bool { return false }},
+	} {
+		md := goldmark.New(goldmark.WithExtensions(NewFancyLists(tc.opts...)))
+		for _, source := range sources {
+			doc := md.Parser().Parse(text.NewReader([]byte(source)))
+			_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+				list, ok := n.(*ast.List)
+				if !ok || !entering {
+					return ast.WalkContinue, nil
+				}
+				_, ours := list.Attribute(attrNameMarker)
+				if !ours && !tc.core(list) {
+					t.Errorf("%s: core parser opened a list in:\n%s", tc.name, source)
+				}
+				for item := list.FirstChild(); item != nil; item = item.NextSibling() {
+					if _, fancy := item.Attribute(attrNameMarker); fancy != ours {
+						t.Errorf("%s: list opened by one parser has an item from the other in:\n%s", tc.name, source)
+					}
+				}
+				return ast.WalkContinue, nil
+			})
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package fancylists
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// Pathological inputs must convert without panicking, and fancy-only markers
+// must not nest deeper than maxNestingDepth.
+var casesPathological = []struct {
+	desc   string
+	source string
+}{
+	{"10k-letter marker prefix", strings.Repeat("a", 10000) + ". item\n"},
+	{"10k-digit marker prefix", strings.Repeat("1", 10000) + ". item\n"},
+	{"10k-hash marker prefix", strings.Repeat("#", 10000) + ". item\n"},
+	{"10k leading spaces", strings.Repeat(" ", 10000) + "a. item\n"},
+	{"10k spaces after marker", "a." + strings.Repeat(" ", 10000) + "item\n"},
+	{"fancy markers nested on one line", strings.Repeat("a. ", 10000) + "item\n"},
+	{"hash markers nested on one line", strings.Repeat("#. ", 10000) + "item\n"},
+	{"nested blockquote and fancy list", strings.Repeat("> a. ", 2000) + "item\n"},
+	{"nested blockquote and numeric list", strings.Repeat("> 1. ", 2000) + "item\n"},
+	{"enormous single item line", "i. " + strings.Repeat("word ", 200000) + "\n"},
+	{"thousands of empty items", strings.Repeat("a.\n", 5000)},
+	{"marker at end of input", "a."},
+	{"delimiter only", ".\n)\n#\n"},
+}
+
+func TestPathologicalInputs(t *testing.T) {
+	for _, c := range casesPathological {
+		t.Run(c.desc, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := mdBasic.Convert([]byte(c.source), &out); err != nil {
+				t.Fatalf("Convert() error: %v", err)
+			}
+			if strings.HasPrefix(c.desc, "fancy markers") || strings.HasPrefix(c.desc, "hash markers") {
+				if got := strings.Count(out.String(), "<ol"); got > maxNestingDepth {
+					t.Errorf("rendered %d nested lists, want at most %d", got, maxNestingDepth)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package fancylists
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+// attrNamePrefix holds the text written before the counter of prefixed
+// markers, such as "Step " in "Step 1.".
+var attrNamePrefix = []byte("fl-prefix")
+
+// Marker prefixes enabled by WithStepMarkers and WithLegalMarkers.
+const (
+	stepPrefix    = "Step "
+	sectionPrefix = "§ "
+	articlePrefix = "Article "
+)
+
+// markerPrefixes returns the configured marker prefixes.
+func (e *FancyListsOptions) markerPrefixes() []string {
+	var prefixes []string
+	if e.StepMarkers {
+		prefixes = append(prefixes, stepPrefix)
+	}
+	if e.LegalMarkers {
+		prefixes = append(prefixes, sectionPrefix, articlePrefix)
+	}
+	return append(prefixes, e.MarkerPrefixes...)
+}
+
+// romanPrefix reports whether counters after prefix are read as roman
+// numerals whenever they are valid ones, as in "Article IV.".
+func (e *FancyListsOptions) romanPrefix(prefix []byte) bool {
+	return e.LegalMarkers && bytes.EqualFold(prefix, []byte(articlePrefix))
+}
+
+// triggers returns listTriggers plus the lead bytes of the non-ASCII
+// markers the options enable, and the first bytes of the configured marker
+// prefixes and bullets, such as the lead bytes of '§' and '•'.
+func (e *FancyListsOptions) triggers() []byte {
+	triggers := append([]byte(nil), listTriggers[:]...)
+	add := func(b byte) {
+		if bytes.IndexByte(triggers, b) < 0 {
+			triggers = append(triggers, b)
+		}
+	}
+	if e.FullWidthMarkers {
+		add(0xEF) // lead byte of the full-width forms
+	}
+	if e.ArabicIndicDigits {
+		add(0xD9) // lead bytes of the Arabic-Indic and Persian digits
+		add(0xDB)
+	}
+	if e.UnicodeRoman || e.ParenthesizedMarkers {
+		add(0xE2) // lead byte of the roman numerals, parenthesized digits and small letters
+	}
+	if e.ParenthesizedMarkers {
+		add(0xF0) // lead byte of the parenthesized capital letters
+	}
+	for _, prefix := range e.markerPrefixes() {
+		if prefix != "" {
+			add(prefix[0])
+		}
+	}
+	for _, bullet := range e.bullets() {
+		add(string(bullet)[0])
+	}
+	return triggers
+}
+
+// mayBeMarker is mayBeListItem, except that lines starting with one of the
+// configured marker prefixes, whose delimiter need not be near the start of
+// the line, always pass.
+func (e *FancyListsOptions) mayBeMarker(line []byte) bool {
+	if mayBeListItem(line) {
+		return true
+	}
+	i := leadingSpaces(line)
+	if i > 3 {
+		return false
+	}
+	line = line[i:]
+	return e.StepMarkers && hasPrefixFold(line, stepPrefix) ||
+		e.LegalMarkers && (hasPrefixFold(line, sectionPrefix) || hasPrefixFold(line, articlePrefix)) ||
+		e.hasMarkerPrefix(line)
+}
+
+// hasMarkerPrefix reports whether line starts with one of MarkerPrefixes.
+func (e *FancyListsOptions) hasMarkerPrefix(line []byte) bool {
+	for _, prefix := range e.MarkerPrefixes {
+		if prefix != "" && hasPrefixFold(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPrefixFold reports whether line starts with prefix, ignoring case.
+func hasPrefixFold(line []byte, prefix string) bool {
+	return len(line) >= len(prefix) && bytes.EqualFold(line[:len(prefix)], []byte(prefix))
+}
+
+// matchPrefixedItem matches an ordered marker directly preceded by one of
+// the configured prefixes, ignoring case. The match starts at the prefix
+// (match[1]) and its marker at the counter (match[2]), so markerText and
+// markerSegment see "1." in "Step 1.".
+func (e *FancyListsOptions) matchPrefixedItem(source []byte, strict bool) ([6]int, listItemType) {
+	i := leadingSpaces(source)
+	if i > 3 {
+		return [6]int{}, notList
+	}
+	for _, prefix := range e.markerPrefixes() {
+		p := i + len(prefix)
+		if p >= len(source) || source[p] == ' ' || !bytes.EqualFold(source[i:p], []byte(prefix)) {
+			continue
+		}
+		m, typ := e.matchMarker(source[p:], strict)
+		if typ != orderedList && typ != orderedListFancy || !prefixedCounter(markerText(source[p:], m)) {
+			continue
+		}
+		for j := range m {
+			if m[j] >= 0 {
+				m[j] += p
+			}
+		}
+		m[0], m[1] = 0, i
+		return m, typ
+	}
+	return [6]int{}, notList
+}
+
+// prefixedCounter reports whether marker may follow a prefix: a number, a
+// single letter, a roman numeral or a '#' marker. Longer letter runs are
+// rejected so a prefix such as "Task " does not turn "Task force." into a
+// marker.
+func prefixedCounter(marker []byte) bool {
+	if len(marker) <= 1 || marker[0] == '#' || !isASCIILetter(marker[0]) {
+		return true
+	}
+	_, ok := anyRomanToNumber(marker)
+	return ok
+}
+
+// markerPrefix returns the prefix of a matched marker, or nil.
+func markerPrefix(line []byte, match [6]int) []byte {
+	if match[2] <= match[1] {
+		return nil
+	}
+	return line[match[1]:match[2]]
+}
+
+// listPrefix returns the marker prefix recorded for a list, or nil.
+func listPrefix(n ast.Node) []byte {
+	if v, ok := n.Attribute(attrNamePrefix); ok {
+		if prefix, ok := v.([]byte); ok {
+			return prefix
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package fancylists
+
+// Presets bundle a coherent set of options into a single Option. They are
+// applied in order with any other options, so an option listed after a
+// preset overrides the setting the preset chose:
+//
+//	NewFancyLists(PandocCompat(), WithItemValues())
+
+// PandocCompat follows Pandoc's fancy_lists reading as closely as the
+// extension allows: markers mixing upper and lower case ("Ii.") are not
+// list markers, and the standalone i/v/x markers use the context rules.
+func PandocCompat() Option {
+	return func(e *FancyListsOptions) {
+		e.MixedCase = MixedCaseReject
+		e.AmbiguousMarkers = AmbiguousContext
+	}
+}
+
+// CommonMarkStrict renders CommonMark lists exactly as Goldmark's core list
+// renderer does (see WithCommonMarkOutput) and accepts only ASCII markers,
+// without mixed case, so fancy markers are the only extension to the syntax.
+func CommonMarkStrict() Option {
+	return func(e *FancyListsOptions) {
+		e.CommonMark = true
+		e.MixedCase = MixedCaseReject
+		e.FullWidthMarkers = false
+		e.ArabicIndicDigits = false
+		e.UnicodeRoman = false
+		e.ParenthesizedMarkers = false
+		e.Bullets = ""
+		e.DashBullets = false
+	}
+}
+
+// HTML5Semantic expresses numbering with attributes HTML5 defines rather than
+// presentational classes: every ordered item carries its value, and the width
+// of zero-padded markers is written as data-padding.
+func HTML5Semantic() Option {
+	return func(e *FancyListsOptions) {
+		e.ItemValues = true
+		e.Padding = PaddingDataAttribute
+	}
+}
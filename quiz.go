@@ -0,0 +1,52 @@
+package fancylists
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/util"
+)
+
+// attrNameQuiz is the list attribute naming the radio group of a quiz, as
+// in "{quiz=q1}".
+var attrNameQuiz = []byte("quiz")
+
+// quizName returns the radio group name of a list rendered as a quiz: an
+// uppercase alphabetic list with a non-empty quiz attribute. It returns nil
+// for other lists and when Quizzes is off.
+func (e *FancyListsOptions) quizName(n ast.Node) []byte {
+	list, ok := n.(*ast.List)
+	if !e.Quizzes || !ok || list == nil || !list.IsOrdered() || listType(list) != "A" {
+		return nil
+	}
+	v, ok := list.Attribute(attrNameQuiz)
+	if !ok {
+		return nil
+	}
+	var name []byte
+	switch v := v.(type) {
+	case []byte:
+		name = v
+	case string:
+		name = []byte(v)
+	}
+	if len(bytes.TrimSpace(name)) == 0 {
+		return nil
+	}
+	return name
+}
+
+// writeQuizInput opens the label of a quiz item and writes its radio input,
+// whose value is the item's letter.
+func (e *FancyListsOptions) writeQuizInput(w util.BufWriter, item ast.Node, name []byte, xhtml bool) {
+	letter := e.label(itemValue(item), "A", 0)
+	_, _ = w.WriteString(`<label><input type="radio" name="`)
+	_, _ = w.Write(util.EscapeHTML(name))
+	_, _ = w.WriteString(`" value="`)
+	_, _ = w.Write(util.EscapeHTML(letter))
+	if xhtml {
+		_, _ = w.WriteString(`" /> `)
+	} else {
+		_, _ = w.WriteString(`"> `)
+	}
+}
@@ -0,0 +1,164 @@
+package fancylists
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// defaultRegion names the fenced containers of WithFancyRegions when no
+// name is given.
+const defaultRegion = "fancy"
+
+// kindFancyRegion is the NodeKind of a "::: fancy" container opened when
+// WithFancyRegions is enabled.
+var kindFancyRegion = ast.NewNodeKind("FancyRegion")
+
+// fancyRegion is a fenced container inside which fancy markers are
+// honored. It renders nothing of its own, only its children.
+type fancyRegion struct {
+	ast.BaseBlock
+}
+
+// Kind implements ast.Node.Kind.
+func (n *fancyRegion) Kind() ast.NodeKind {
+	return kindFancyRegion
+}
+
+// Dump implements ast.Node.Dump.
+func (n *fancyRegion) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+// matchRegionFence matches a fence of at least three colons indented at
+// most three spaces, as in Pandoc's fenced divs, and returns the name
+// written after it, without any closing colons: "fancy" for "::: fancy :::"
+// and "" for a bare closing fence.
+func matchRegionFence(line []byte) ([]byte, bool) {
+	i := 0
+	for ; i < len(line) && i <= 3 && line[i] == ' '; i++ {
+	}
+	colons := i
+	for ; i < len(line) && line[i] == ':'; i++ {
+	}
+	if i-colons < 3 {
+		return nil, false
+	}
+	name := bytes.TrimRight(util.TrimRightSpace(line[i:]), ":")
+	return util.TrimRightSpace(util.TrimLeftSpace(name)), true
+}
+
+// fancyRegionParser opens a fancyRegion at a "::: name" fence naming the
+// configured region and closes it at the next bare ":::" fence, or at the
+// end of the enclosing block.
+type fancyRegionParser struct {
+	name []byte
+}
+
+func (b *fancyRegionParser) Trigger() []byte {
+	return []byte{':'}
+}
+
+func (b *fancyRegionParser) Open(parent ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	line, _ := reader.PeekLine()
+	if name, ok := matchRegionFence(line); !ok || !bytes.Equal(name, b.name) {
+		return nil, parser.NoChildren
+	}
+	reader.AdvanceToEOL()
+	return &fancyRegion{}, parser.HasChildren
+}
+
+func (b *fancyRegionParser) Continue(node ast.Node, reader text.Reader, pc parser.Context) parser.State {
+	line, _ := reader.PeekLine()
+	if name, ok := matchRegionFence(line); ok && len(name) == 0 {
+		reader.AdvanceToEOL()
+		return parser.Close
+	}
+	return parser.Continue | parser.HasChildren
+}
+
+func (b *fancyRegionParser) Close(node ast.Node, reader text.Reader, pc parser.Context) {
+}
+
+func (b *fancyRegionParser) CanInterruptParagraph() bool {
+	return true
+}
+
+func (b *fancyRegionParser) CanAcceptIndentedLine() bool {
+	return false
+}
+
+// fancyRegionHTMLRenderer renders the children of a region without a
+// wrapping element, so the fences leave no trace in the output.
+type fancyRegionHTMLRenderer struct{}
+
+func (r *fancyRegionHTMLRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(kindFancyRegion, func(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+		return ast.WalkContinue, nil
+	})
+}
+
+// fancyIn reports whether fancy markers are honored in n and the blocks
+// nested in it: everywhere unless WithFancyRegions is enabled, and then
+// only inside a region.
+func (e *FancyListsOptions) fancyIn(n ast.Node) bool {
+	if e.Regions == "" {
+		return true
+	}
+	for ; n != nil; n = n.Parent() {
+		if n.Kind() == kindFancyRegion {
+			return true
+		}
+	}
+	return false
+}
+
+// matchListItemIn is matchListItem for a line in n. Where fancy markers
+// are not honored only the markers CommonMark defines match.
+func (e *FancyListsOptions) matchListItemIn(n ast.Node, line []byte, strict bool) ([6]int, listItemType) {
+	m, typ := e.matchListItem(line, strict)
+	if typ != notList && !e.fancyIn(n) && !isCommonMarkMarker(line, m, typ) {
+		return m, notList
+	}
+	return m, typ
+}
+
+// matchBareHashItemIn is matchBareHashItem for the reader's current line in
+// n. A bare '#' only continues an item directly: after a blank line it is
+// an ATX heading.
+func (e *FancyListsOptions) matchBareHashItemIn(n ast.Node, reader text.Reader, line []byte) ([6]int, listItemType) {
+	if !e.fancyIn(n) || followsBlankLine(reader) {
+		return [6]int{}, notList
+	}
+	return e.matchBareHashItem(line)
+}
+
+// isCommonMarkMarker reports whether the marker matched in line is one
+// CommonMark defines: an ASCII bullet, or one to nine ASCII digits followed
+// by '.' or ')', indented at most three spaces and without a prefix.
+func isCommonMarkMarker(line []byte, match [6]int, typ listItemType) bool {
+	if match[1] > 3 {
+		return false
+	}
+	marker := line[match[1]:match[3]]
+	switch typ {
+	case bulletList:
+		return len(marker) == 1 && (marker[0] == '-' || marker[0] == '*' || marker[0] == '+')
+	case orderedList:
+		digits := len(marker) - 1
+		if digits < 1 || digits > 9 || (marker[digits] != '.' && marker[digits] != ')') {
+			return false
+		}
+		for _, c := range marker[:digits] {
+			if !util.IsNumeric(c) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package fancylists
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+// renumberTransformer numbers every ordered list 1, 2, 3, ... whatever its
+// markers say, for WithRenumbering. Lists no longer count down and items
+// lose the values set with "#5." markers.
+type renumberTransformer struct{}
+
+func (t *renumberTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		list, ok := n.(*ast.List)
+		if !ok || !entering || !list.IsOrdered() {
+			return ast.WalkContinue, nil
+		}
+		list.Start = 1
+		removeAttribute(list, attrNameReversed)
+		value := 1
+		for item := list.FirstChild(); item != nil; item = item.NextSibling() {
+			if _, ok := item.Attribute(attrNameValue); ok {
+				item.SetAttribute(attrNameValue, value)
+			}
+			removeAttribute(item, attrNameExplicit)
+			value++
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+// removeAttribute removes the named attribute of n, if set.
+func removeAttribute(n ast.Node, name []byte) {
+	if _, ok := n.Attribute(name); !ok {
+		return
+	}
+	attrs := n.Attributes()
+	n.RemoveAttributes()
+	for _, attr := range attrs {
+		if !bytes.Equal(attr.Name, name) {
+			n.SetAttribute(attr.Name, attr.Value)
+		}
+	}
+}
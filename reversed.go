@@ -0,0 +1,44 @@
+package fancylists
+
+import (
+	"github.com/yuin/goldmark/ast"
+)
+
+// attrNameReversed marks an ordered list whose markers count down. Like the
+// other internal attributes it is never rendered as is.
+var attrNameReversed = []byte("fl-reversed")
+
+// markReversed marks list as reversed when it has at least two items and
+// each item's marker is one less than the marker above it, as in "3. 2. 1."
+// or "iii. ii. i.". The item values are updated to count down as well.
+// Lists with a '#' marker anywhere never count down.
+func (e *FancyListsOptions) markReversed(list *ast.List, source []byte) {
+	if !list.IsOrdered() || list.ChildCount() < 2 {
+		return
+	}
+	typ := listType(list)
+	expected := list.Start
+	for item := list.FirstChild(); item != nil; item = item.NextSibling() {
+		segment, ok := MarkerSegment(item)
+		if !ok {
+			return
+		}
+		marker := markerText(source, [6]int{0, 0, segment.Start, segment.Stop, -1, -1})
+		if len(marker) == 0 || marker[0] == '#' || e.uncappedHintValue(marker, typ) != expected {
+			return
+		}
+		expected--
+	}
+	list.SetAttribute(attrNameReversed, true)
+	value := list.Start
+	for item := list.FirstChild(); item != nil; item = item.NextSibling() {
+		item.SetAttribute(attrNameValue, value)
+		value--
+	}
+}
+
+// isReversed reports whether markReversed marked the list.
+func isReversed(n ast.Node) bool {
+	_, ok := n.Attribute(attrNameReversed)
+	return ok
+}
@@ -0,0 +1,78 @@
+package fancylists
+
+import "unicode/utf8"
+
+// Digit scripts accepted when ArabicIndicDigits is enabled.
+const (
+	arabicIndicZero         = '٠' // U+0660
+	arabicIndicNine         = '٩' // U+0669
+	extendedArabicIndicZero = '۰' // U+06F0, used for Persian and Urdu
+	extendedArabicIndicNine = '۹' // U+06F9
+)
+
+// Values of the internal digits attribute, also written as data-digits.
+var (
+	digitsArabicIndic = []byte("arabic-indic")
+	digitsPersian     = []byte("persian")
+)
+
+// foldArabicIndic maps Arabic-Indic and extended Arabic-Indic digits to
+// their ASCII forms. Other runes are returned unchanged.
+func foldArabicIndic(r rune) rune {
+	switch {
+	case r >= arabicIndicZero && r <= arabicIndicNine:
+		return r - arabicIndicZero + '0'
+	case r >= extendedArabicIndicZero && r <= extendedArabicIndicNine:
+		return r - extendedArabicIndicZero + '0'
+	}
+	return r
+}
+
+// parseArabicListItem is the counterpart of parseListItem for numeric
+// markers written with Arabic-Indic ("١.") or extended Arabic-Indic ("۱)")
+// digits. The two scripts cannot be mixed within one marker.
+func parseArabicListItem(line []byte) ([6]int, listItemType) {
+	ret := [6]int{}
+	i := 0
+	l := len(line)
+	for ; i < l && i <= 3 && line[i] == ' '; i++ {
+	}
+	if i > 3 || i >= l {
+		return ret, notList
+	}
+	ret[1] = i
+	ret[2] = i
+
+	first, _ := utf8.DecodeRune(line[i:])
+	digits := 0
+	for i < l {
+		r, size := utf8.DecodeRune(line[i:])
+		if foldArabicIndic(r) == r || (r >= extendedArabicIndicZero) != (first >= extendedArabicIndicZero) {
+			break
+		}
+		digits++
+		i += size
+	}
+	if digits == 0 || digits > 9 {
+		return ret, notList
+	}
+	if i >= l || (line[i] != '.' && line[i] != ')') {
+		return ret, notList
+	}
+	i++
+	ret[3] = i
+	return finishListItem(line, i, ret, orderedList)
+}
+
+// markerDigits returns the digit script of a matched numeric marker, or nil
+// for ASCII and full-width digits.
+func markerDigits(line []byte, match [6]int) []byte {
+	r, _ := utf8.DecodeRune(line[match[2]:])
+	switch {
+	case r >= arabicIndicZero && r <= arabicIndicNine:
+		return digitsArabicIndic
+	case r >= extendedArabicIndicZero && r <= extendedArabicIndicNine:
+		return digitsPersian
+	}
+	return nil
+}
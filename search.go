@@ -0,0 +1,88 @@
+package fancylists
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+// IndexItem describes one list item for a site search index.
+type IndexItem struct {
+	// Label is the item's marker without its delimiter, such as "iv" or
+	// "B", computed from the item's value for '#' markers. It is empty for
+	// bullet items.
+	Label string
+	// Depth is the number of lists containing the item's list; items of a
+	// top-level list have depth 0.
+	Depth int
+	// Text is the plain text of the item's first block.
+	Text string
+	// Line is the 1-based source line where the item starts.
+	Line int
+	// Offset is the byte offset in the source where the item starts: its
+	// marker for items parsed by this extension, otherwise its first line
+	// of content. It is -1 for empty items that have neither.
+	Offset int
+}
+
+// IndexItems returns every list item of a parsed document in document
+// order, with its computed label, depth, plain text and source position,
+// so search indexes can show results in context ("step iv") without
+// walking the AST themselves.
+func IndexItems(doc ast.Node, source []byte) []IndexItem {
+	e := &FancyListsOptions{}
+	var items []IndexItem
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		list, ok := n.(*ast.List)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+		depth := listDepth(list)
+		value := list.Start
+		for item := list.FirstChild(); item != nil; item = item.NextSibling() {
+			if _, ok := item.Attribute(attrNameValue); ok {
+				value = itemValue(item)
+			}
+			entry := IndexItem{Depth: depth, Offset: itemOffset(item)}
+			if list.IsOrdered() {
+				entry.Label = string(e.itemLabel(item, list, value, source))
+			}
+			if block := item.FirstChild(); block != nil && block.Kind() != ast.KindList {
+				entry.Text = string(plainText(block, source))
+			}
+			if entry.Offset >= 0 {
+				entry.Line = bytes.Count(source[:entry.Offset], []byte{'\n'}) + 1
+			}
+			items = append(items, entry)
+			value++
+		}
+		return ast.WalkContinue, nil
+	})
+	return items
+}
+
+// itemLabel returns the marker of an ordered list item without its
+// delimiter: the marker as written, folded to ASCII, or for '#' markers and
+// items this extension did not parse the marker value would be written with.
+func (e *FancyListsOptions) itemLabel(item ast.Node, list *ast.List, value int, source []byte) []byte {
+	if segment, ok := MarkerSegment(item); ok {
+		marker := segment.Value(source)
+		if len(marker) > 0 && marker[0] != '#' {
+			return markerText(marker, [6]int{3: len(marker)})
+		}
+	}
+	return e.label(value, listType(list), listPadding(list))
+}
+
+// itemOffset returns the source offset where item starts, or -1.
+func itemOffset(item ast.Node) int {
+	if segment, ok := MarkerSegment(item); ok {
+		return segment.Start
+	}
+	for c := item.FirstChild(); c != nil; c = c.FirstChild() {
+		if c.Type() == ast.TypeBlock && c.Lines().Len() > 0 {
+			return c.Lines().At(0).Start
+		}
+	}
+	return -1
+}
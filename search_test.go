@@ -0,0 +1,34 @@
+package fancylists
+
+import (
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/text"
+)
+
+func TestIndexItems(t *testing.T) {
+	md := goldmark.New(goldmark.WithExtensions(NewFancyLists()))
+	source := []byte(`Steps:
+
+iii. Open the *lid*
+#. Pour
+   - Slowly
+   - Evenly
+`)
+	items := IndexItems(md.Parser().Parse(text.NewReader(source)), source)
+	want := []IndexItem{
+		{Label: "iii", Depth: 0, Text: "Open the lid", Line: 3, Offset: 8},
+		{Label: "iv", Depth: 0, Text: "Pour", Line: 4, Offset: 28},
+		{Label: "", Depth: 1, Text: "Slowly", Line: 5, Offset: 39},
+		{Label: "", Depth: 1, Text: "Evenly", Line: 6, Offset: 51},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("items = %+v; want %+v", items, want)
+	}
+	for i := range want {
+		if items[i] != want[i] {
+			t.Errorf("item %d = %+v; want %+v", i, items[i], want[i])
+		}
+	}
+}
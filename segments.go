@@ -0,0 +1,34 @@
+package fancylists
+
+import (
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// attrNameMarker holds the text.Segment of the marker that opened a list or
+// list item. Like the other internal attributes it is never rendered.
+var attrNameMarker = []byte("fl-marker")
+
+// markerSegment returns the source range of the marker matched on the
+// reader's current line, delimiter included.
+func markerSegment(reader text.Reader, match [6]int) text.Segment {
+	_, pos := reader.Position()
+	// The line returned by PeekLine starts with pos.Padding spaces that are
+	// not in the source
+	start := pos.Start - pos.Padding
+	return text.NewSegment(start+match[2], start+match[3])
+}
+
+// MarkerSegment returns the source range of the marker, delimiter included,
+// that opened a list item parsed by this extension ("iv)" in "iv) text").
+// For a list it returns the marker of the first item. The item's content is
+// available from the Lines of its child blocks, as for any Goldmark node.
+// It reports false for nodes that were not created by this extension.
+func MarkerSegment(n ast.Node) (text.Segment, bool) {
+	v, ok := n.Attribute(attrNameMarker)
+	if !ok {
+		return text.Segment{}, false
+	}
+	segment, ok := v.(text.Segment)
+	return segment, ok
+}
@@ -0,0 +1,43 @@
+package fancylists
+
+import (
+	"testing"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+func TestMarkerSegment(t *testing.T) {
+	source := []byte("a. One\nb) Two\n   iv. Nested\n\n> C. Quoted\n\n-\tTabbed\n  1. Inner\n")
+	doc := mdBasic.Parser().Parse(text.NewReader(source))
+
+	var markers []string
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if _, ok := n.(*ast.ListItem); ok && entering {
+			segment, ok := MarkerSegment(n)
+			if !ok {
+				t.Errorf("list item at %q has no marker segment", n.Parent().Kind())
+				return ast.WalkContinue, nil
+			}
+			markers = append(markers, string(segment.Value(source)))
+		}
+		return ast.WalkContinue, nil
+	})
+	want := []string{"a.", "b)", "iv.", "C.", "-", "1."}
+	if len(markers) != len(want) {
+		t.Fatalf("markers = %q; want %q", markers, want)
+	}
+	for i := range want {
+		if markers[i] != want[i] {
+			t.Errorf("marker %d = %q; want %q", i, markers[i], want[i])
+		}
+	}
+
+	list := doc.FirstChild()
+	if segment, ok := MarkerSegment(list); !ok || string(segment.Value(source)) != "a." {
+		t.Errorf("list marker = %q, %v; want %q", segment.Value(source), ok, "a.")
+	}
+	if _, ok := MarkerSegment(doc); ok {
+		t.Errorf("document has a marker segment")
+	}
+}
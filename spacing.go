@@ -0,0 +1,71 @@
+package fancylists
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+// attrNameSpacing holds the "{loose}" or "{tight}" keyword given to a list
+// with WithBlockAttributes.
+var attrNameSpacing = []byte("fl-spacing")
+
+// Spacing keywords that override whether a list is tight.
+var (
+	spacingLoose = []byte("loose")
+	spacingTight = []byte("tight")
+)
+
+// setSpacing makes list tight or loose regardless of the blank lines in it:
+// the first blocks of its items become text blocks in a tight list and
+// paragraphs in a loose one.
+func setSpacing(list *ast.List, tight bool) {
+	list.IsTight = tight
+	for item := list.FirstChild(); item != nil; item = item.NextSibling() {
+		for c := item.FirstChild(); c != nil; {
+			next := c.NextSibling()
+			var block ast.Node
+			switch c.Kind() {
+			case ast.KindParagraph:
+				if tight {
+					block = ast.NewTextBlock()
+				}
+			case ast.KindTextBlock:
+				if !tight {
+					block = ast.NewParagraph()
+				}
+			}
+			if block != nil {
+				block.SetLines(c.Lines())
+				block.SetBlankPreviousLines(c.HasBlankPreviousLines())
+				for gc := c.FirstChild(); gc != nil; gc = c.FirstChild() {
+					block.AppendChild(block, gc)
+				}
+				item.ReplaceChild(item, c, block)
+			}
+			c = next
+		}
+	}
+}
+
+// spacingTransformer applies the "{loose}" and "{tight}" keywords read by
+// the block attribute parser (see WithBlockAttributes). Lists are only
+// closed, and their tightness computed, after the keyword line below them
+// is parsed, so this has to wait until parsing is done. Classes are user
+// attributes like any other and never change a list's spacing.
+type spacingTransformer struct{}
+
+func (t *spacingTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		list, ok := n.(*ast.List)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+		if v, ok := list.Attribute(attrNameSpacing); ok {
+			setSpacing(list, bytes.Equal(v.([]byte), spacingTight))
+		}
+		return ast.WalkContinue, nil
+	})
+}
@@ -0,0 +1,165 @@
+package fancylists
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+var updateSpec = flag.Bool("update-spec", false, "rewrite fltest/spec/cases.json from the test cases")
+
+// specFile is the conformance corpus shipped with the fltest package.
+const specFile = "fltest/spec/cases.json"
+
+// specCase mirrors fltest.SpecCase, which this package cannot import.
+type specCase struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Options     json.RawMessage `json:"options,omitempty"`
+	GFM         bool            `json:"gfm,omitempty"`
+	Markdown    string          `json:"markdown"`
+	HTML        string          `json:"html"`
+}
+
+// specOptions returns the fields opts sets, as a JSON object, or nil when
+// it sets none. Fields keeping their default value are left out.
+func specOptions(opts ...Option) (json.RawMessage, error) {
+	defaults, err := optionFields(NewFancyLists())
+	if err != nil {
+		return nil, err
+	}
+	fields, err := optionFields(NewFancyLists(opts...))
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range fields {
+		if reflect.DeepEqual(value, defaults[name]) {
+			delete(fields, name)
+		}
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(fields)
+}
+
+// optionFields returns the fields of e as decoded from its JSON form.
+func optionFields(e *FancyListsOptions) (map[string]interface{}, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	err = json.Unmarshal(data, &fields)
+	return fields, err
+}
+
+// specName returns the name of a case in section: its description in lower
+// case, with each run of characters other than letters and digits replaced
+// by '-', so that adding a case does not rename the others. A description
+// repeated in the section is numbered from its second occurrence.
+func specName(section, desc string, seen map[string]int) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(desc) {
+		if r < utf8.RuneSelf && (r >= 'a' && r <= 'z' || r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	name := section + "/" + b.String()
+	seen[name]++
+	if n := seen[name]; n > 1 {
+		name += "-" + strconv.Itoa(n)
+	}
+	return name
+}
+
+// specCorpus builds the conformance corpus from the cases that need no
+// Goldmark extension besides GFM.
+func specCorpus() ([]specCase, error) {
+	var cases []specCase
+	seen := map[string]int{}
+	add := func(section string, desc string, md string, html string, gfm bool, opts ...Option) error {
+		name := specName(section, desc, seen)
+		options, err := specOptions(opts...)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		cases = append(cases, specCase{
+			Name:        name,
+			Description: desc,
+			Options:     options,
+			GFM:         gfm,
+			Markdown:    md,
+			HTML:        html,
+		})
+		return nil
+	}
+	for _, c := range casesBasic {
+		if err := add("basic", c.desc, c.md, c.html, false); err != nil {
+			return nil, err
+		}
+	}
+	for _, c := range casesGeneral {
+		if err := add("general", c.desc, c.md, c.html, true); err != nil {
+			return nil, err
+		}
+	}
+	for _, c := range casesCompact {
+		if err := add("compact", c.desc, c.md, c.html, false, WithCompact()); err != nil {
+			return nil, err
+		}
+	}
+	for _, c := range casesOptions {
+		if c.blockAttributes {
+			continue
+		}
+		if err := add("options", c.desc, c.md, c.html, false, c.options...); err != nil {
+			return nil, err
+		}
+	}
+	return cases, nil
+}
+
+// TestSpecCorpus checks that the shipped corpus matches the test cases. Run
+// it with -update-spec after changing them.
+func TestSpecCorpus(t *testing.T) {
+	cases, err := specCorpus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(cases); err != nil {
+		t.Fatal(err)
+	}
+	want := buf.Bytes()
+	if *updateSpec {
+		if err := os.WriteFile(specFile, want, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	got, err := os.ReadFile(specFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s is out of date; run go test -run TestSpecCorpus -update-spec", specFile)
+	}
+}
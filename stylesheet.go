@@ -0,0 +1,114 @@
+package fancylists
+
+import "strings"
+
+// listStyleTypes maps ordered list type attribute values to CSS list-style-type values.
+var listStyleTypes = [...]struct {
+	typ   string
+	style string
+}{
+	{"1", "decimal"},
+	{"a", "lower-alpha"},
+	{"A", "upper-alpha"},
+	{"i", "lower-roman"},
+	{"I", "upper-roman"},
+}
+
+// attrNameStyle is the attribute WithInlineStyles writes.
+var attrNameStyle = []byte("style")
+
+// listStyleType returns the CSS list-style-type of an ordered list type.
+func listStyleType(typ string) string {
+	for _, s := range listStyleTypes {
+		if s.typ == typ {
+			return s.style
+		}
+	}
+	return "decimal"
+}
+
+// inlineListStyle returns the declaration WithInlineStyles writes for a list
+// of type typ, or bullet list with class bulletClass, matching the rule
+// Stylesheet gives its classes: none when hidden, as for ClassMarked and
+// ClassChecklist lists. It returns "" for bullet lists without a class.
+func inlineListStyle(ordered bool, typ, bulletClass string, hidden bool) string {
+	switch {
+	case hidden:
+		return "list-style-type: none"
+	case ordered:
+		return "list-style-type: " + listStyleType(typ)
+	case bulletClass != "":
+		return "list-style-type: " + strings.TrimPrefix(bulletClass, "fl-")
+	}
+	return ""
+}
+
+// Stylesheet returns a minimal CSS stylesheet for the classes in m, plus the
+// rules hiding the numbers of ClassChecklist and ClassMarked lists and
+// styling the bullet list classes. Types m does not map, or all of them if m
+// is nil, use their default class, as with WithClassMap.
+func Stylesheet(m ClassMap) string {
+	m = defaultClassMap.with(m)
+	var sb strings.Builder
+	for _, s := range listStyleTypes {
+		sb.WriteString("ol.")
+		sb.WriteString(m.Class(s.typ))
+		sb.WriteString(" { list-style-type: ")
+		sb.WriteString(s.style)
+		sb.WriteString("; }\n")
+	}
+	sb.WriteString("ol." + ClassChecklist + " { list-style-type: none; }\n")
+	sb.WriteString("ol." + ClassMarked + ", ul." + ClassMarked + " { list-style-type: none; }\n")
+	for _, class := range bulletClasses {
+		// The classes name their list-style-type without the prefix
+		sb.WriteString("ul." + class + " { list-style-type: " + strings.TrimPrefix(class, "fl-") + "; }\n")
+	}
+	return sb.String()
+}
+
+// Counter style names used by CounterStyles.
+const (
+	CounterStyleLowerAlphabet = "fl-lower-alphabet"
+	CounterStyleUpperAlphabet = "fl-upper-alphabet"
+)
+
+// CounterStyles returns CSS @counter-style rules for the alphabet passed to
+// WithAlphabet, and rules applying them to the alphabetic list classes in m,
+// so browsers number items with the same letters the parser accepted rather
+// than a-z. Types m does not map use their default class. It returns "" when
+// alphabet is empty. Include it after Stylesheet so its rules take effect.
+func CounterStyles(alphabet string, m ClassMap) string {
+	if alphabet == "" {
+		return ""
+	}
+	m = defaultClassMap.with(m)
+	var sb strings.Builder
+	for _, s := range [...]struct {
+		typ   string
+		name  string
+		upper bool
+	}{
+		{"a", CounterStyleLowerAlphabet, false},
+		{"A", CounterStyleUpperAlphabet, true},
+	} {
+		sb.WriteString("@counter-style ")
+		sb.WriteString(s.name)
+		sb.WriteString(" { system: alphabetic; symbols:")
+		for i := 0; i < len(alphabet); i++ {
+			c := alphabet[i] | 0x20
+			if s.upper {
+				c &^= 0x20
+			}
+			sb.WriteString(` "`)
+			sb.WriteByte(c)
+			sb.WriteByte('"')
+		}
+		sb.WriteString("; }\n")
+		sb.WriteString("ol.")
+		sb.WriteString(m.Class(s.typ))
+		sb.WriteString(" { list-style-type: ")
+		sb.WriteString(s.name)
+		sb.WriteString("; }\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,94 @@
+package fancylists
+
+import (
+	"github.com/yuin/goldmark/ast"
+)
+
+// TableOfContents builds a table of contents for a parsed document: a nested
+// ordered list with an item linking to each heading, numbered with this
+// extension's list styles so it matches the document's own lists. Lists are
+// typed by depth from types, cycling as for WithHashDepthTypes (1, a, i, A
+// when none are given). Headings without an id are skipped, so parse with
+// parser.WithAutoHeadingID or give headings ids yourself. A heading more
+// than one level below the previous one nests only one level deeper, and
+// headings above the first heading's level are listed at the top level.
+//
+// The list is not part of doc; render it with the Renderer of a
+// goldmark.Markdown using this extension, passing the document's source.
+// TableOfContents returns nil when no heading has an id.
+func TableOfContents(doc ast.Node, source []byte, types ...MarkerType) *ast.List {
+	if len(types) == 0 {
+		types = defaultHashDepthTypes
+	}
+	e := &FancyListsOptions{HashDepthTypes: types}
+
+	type level struct {
+		level int
+		list  *ast.List
+	}
+	var root *ast.List
+	var stack []level
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := n.(*ast.Heading)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+		id, ok := heading.AttributeString("id")
+		if !ok {
+			return ast.WalkSkipChildren, nil
+		}
+		if root == nil {
+			root = tocList(e.hashDepthType(0))
+			stack = append(stack, level{heading.Level, root})
+		}
+		for len(stack) > 1 && heading.Level < stack[len(stack)-1].level {
+			stack = stack[:len(stack)-1]
+		}
+		top := stack[len(stack)-1]
+		if heading.Level > top.level {
+			if parent := top.list.LastChild(); parent != nil {
+				list := tocList(e.hashDepthType(len(stack)))
+				parent.AppendChild(parent, list)
+				top = level{heading.Level, list}
+				stack = append(stack, top)
+			}
+		}
+		top.list.AppendChild(top.list, tocItem(heading, id, source))
+		return ast.WalkSkipChildren, nil
+	})
+	return root
+}
+
+// tocList returns an empty tight ordered list of type typ.
+func tocList(typ string) *ast.List {
+	list := ast.NewList('.')
+	list.Start = 1
+	list.IsTight = true
+	if v := typeValue(typ); v != nil {
+		list.SetAttribute(attrNameListType, v)
+	}
+	return list
+}
+
+// tocItem returns a list item linking to heading by its id.
+func tocItem(heading *ast.Heading, id any, source []byte) *ast.ListItem {
+	link := ast.NewLink()
+	link.Destination = append([]byte{'#'}, idBytes(id)...)
+	link.AppendChild(link, ast.NewString(plainText(heading, source)))
+	block := ast.NewTextBlock()
+	block.AppendChild(block, link)
+	item := ast.NewListItem(0)
+	item.AppendChild(item, block)
+	return item
+}
+
+// idBytes returns a heading id attribute value as bytes.
+func idBytes(id any) []byte {
+	switch id := id.(type) {
+	case []byte:
+		return id
+	case string:
+		return []byte(id)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package fancylists
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+func TestTableOfContents(t *testing.T) {
+	md := goldmark.New(
+		goldmark.WithExtensions(NewFancyLists()),
+		goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+	)
+	source := []byte(`# Guide
+
+## Install & *run*
+
+#### Linux
+
+## Usage
+
+### Flags
+
+# Appendix
+`)
+	doc := md.Parser().Parse(text.NewReader(source))
+	var out bytes.Buffer
+	if err := md.Renderer().Render(&out, source, TableOfContents(doc, source)); err != nil {
+		t.Fatal(err)
+	}
+	want := `<ol class="fancy fl-num" type="1" start="1">
+<li><a href="#guide">Guide</a>
+<ol class="fancy fl-lcalpha" type="a" start="1">
+<li><a href="#install--run">Install &amp; run</a>
+<ol class="fancy fl-lcroman" type="i" start="1">
+<li><a href="#linux">Linux</a></li>
+</ol>
+</li>
+<li><a href="#usage">Usage</a>
+<ol class="fancy fl-lcroman" type="i" start="1">
+<li><a href="#flags">Flags</a></li>
+</ol>
+</li>
+</ol>
+</li>
+<li><a href="#appendix">Appendix</a></li>
+</ol>
+`
+	if out.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	source = []byte("# Title\n")
+	if toc := TableOfContents(goldmark.New().Parser().Parse(text.NewReader(source)), source); toc != nil {
+		t.Errorf("got a table of contents for headings without ids")
+	}
+}
@@ -0,0 +1,66 @@
+package fancylists
+
+import (
+	"fmt"
+
+	"github.com/yuin/goldmark/text"
+)
+
+// TraceKind classifies a TraceEvent.
+type TraceKind int
+
+const (
+	// TraceList records a line that opened a list.
+	TraceList TraceKind = iota
+	// TraceItem records a line that added an item to the open list.
+	TraceItem
+	// TraceNotList records a line that could not open a list.
+	TraceNotList
+	// TraceClose records a line at which a list was closed.
+	TraceClose
+)
+
+// String returns the name of the kind, such as "list" or "close".
+func (k TraceKind) String() string {
+	switch k {
+	case TraceList:
+		return "list"
+	case TraceItem:
+		return "item"
+	case TraceNotList:
+		return "not-list"
+	case TraceClose:
+		return "close"
+	}
+	return fmt.Sprintf("TraceKind(%d)", int(k))
+}
+
+// TraceEvent describes one decision of the list parsers.
+type TraceEvent struct {
+	// Kind is what was decided.
+	Kind TraceKind
+	// Line is the 1-based source line the decision was made on.
+	Line int
+	// Message explains the decision, such as the list type chosen or why a
+	// list was closed.
+	Message string
+}
+
+// String formats the event as "line 3: close: marker type changed".
+func (t TraceEvent) String() string {
+	return fmt.Sprintf("line %d: %s: %s", t.Line, t.Kind, t.Message)
+}
+
+// trace reports a decision made on the reader's current line to the
+// configured Trace hook. Messages passed without args are not formatted.
+func (e *FancyListsOptions) trace(reader text.Reader, kind TraceKind, format string, args ...interface{}) {
+	if e.Trace == nil {
+		return
+	}
+	message := format
+	if len(args) > 0 {
+		message = fmt.Sprintf(format, args...)
+	}
+	line, _ := reader.Position()
+	e.Trace(TraceEvent{Kind: kind, Line: line + 1, Message: message})
+}
@@ -0,0 +1,32 @@
+package fancylists
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/yuin/goldmark"
+)
+
+func TestTrace(t *testing.T) {
+	var events []string
+	md := goldmark.New(goldmark.WithExtensions(NewFancyLists(WithTrace(func(e TraceEvent) {
+		events = append(events, e.String())
+	}))))
+	source := []byte("Intro\nb. not here\n\na. First\nb. Second\n1. Numbers\n")
+	var out bytes.Buffer
+	if err := md.Convert(source, &out); err != nil {
+		t.Fatal(err)
+	}
+	got := strings.Join(events, "\n")
+	want := `line 2: not-list: a list not starting at 1 cannot interrupt a paragraph
+line 4: list: ordered list of type a starting at 1
+line 4: item: item 1
+line 5: item: item 2
+line 6: close: marker type changed from a to 1
+line 6: list: ordered list of type 1 starting at 1
+line 6: item: item 1`
+	if got != want {
+		t.Errorf("trace:\n%s\nwant:\n%s", got, want)
+	}
+}
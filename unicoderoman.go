@@ -0,0 +1,80 @@
+package fancylists
+
+import "unicode/utf8"
+
+// Roman numeral code points accepted when UnicodeRoman is enabled.
+const (
+	unicodeRomanUpper = 'Ⅰ' // U+2160, the first uppercase numeral
+	unicodeRomanLower = 'ⅰ' // U+2170, the first lowercase numeral
+	unicodeRomanLast  = 'ⅿ' // U+217F, lowercase one thousand
+)
+
+// unicodeRomanLetters holds the ASCII spelling of each numeral, in code
+// point order from U+2160 and U+2170: one to twelve, then fifty, one
+// hundred, five hundred and one thousand.
+var unicodeRomanLetters = [16]string{
+	"I", "II", "III", "IV", "V", "VI", "VII", "VIII", "IX", "X", "XI", "XII",
+	"L", "C", "D", "M",
+}
+
+// isUnicodeRoman reports whether r is one of the Unicode roman numeral
+// characters, such as 'Ⅻ' or 'ⅳ'.
+func isUnicodeRoman(r rune) bool {
+	return r >= unicodeRomanUpper && r <= unicodeRomanLast
+}
+
+// foldUnicodeRoman appends the ASCII spelling of the roman numeral r to
+// text, lowercase for the lowercase numerals: 'Ⅻ' becomes "XII".
+func foldUnicodeRoman(text []byte, r rune) []byte {
+	if r >= unicodeRomanLower {
+		for _, c := range []byte(unicodeRomanLetters[r-unicodeRomanLower]) {
+			text = append(text, c-'A'+'a')
+		}
+		return text
+	}
+	return append(text, unicodeRomanLetters[r-unicodeRomanUpper]...)
+}
+
+// parseUnicodeRomanListItem is the counterpart of parseListItem for markers
+// written with the dedicated roman numeral characters, such as "Ⅲ." or
+// "ⅹⅱ)". The numerals of one marker share a case.
+func parseUnicodeRomanListItem(line []byte) ([6]int, listItemType) {
+	ret := [6]int{}
+	i := 0
+	l := len(line)
+	for ; i < l && i <= 3 && line[i] == ' '; i++ {
+	}
+	if i > 3 || i >= l {
+		return ret, notList
+	}
+	ret[1] = i
+	ret[2] = i
+
+	first, _ := utf8.DecodeRune(line[i:])
+	numerals := 0
+	for i < l {
+		r, size := utf8.DecodeRune(line[i:])
+		if !isUnicodeRoman(r) || (r >= unicodeRomanLower) != (first >= unicodeRomanLower) {
+			break
+		}
+		numerals++
+		i += size
+	}
+	if numerals == 0 || numerals > maxMarkerLen {
+		return ret, notList
+	}
+	if i >= l || (line[i] != '.' && line[i] != ')') {
+		return ret, notList
+	}
+	i++
+	ret[3] = i
+	return finishListItem(line, i, ret, orderedListFancy)
+}
+
+// isUnicodeRomanMarker reports whether the marker matched in line is
+// written with roman numeral characters. Such markers are always read as
+// roman numerals, never as letters.
+func isUnicodeRomanMarker(line []byte, match [6]int) bool {
+	r, _ := utf8.DecodeRune(line[match[2]:])
+	return isUnicodeRoman(r)
+}